@@ -0,0 +1,49 @@
+package errors
+
+// Builder assembles an ApiError step by step as an alternative to functional options.
+// Every method returns the builder so calls can be chained.
+type Builder struct {
+	errorType string
+	message   string
+	options   []ErrorOption
+}
+
+// NewBuilder starts building an error of the given type.
+func NewBuilder(errorType string) *Builder {
+	return &Builder{errorType: errorType}
+}
+
+// Message sets the user-facing message. Without it the registry's default message is used.
+func (b *Builder) Message(message string) *Builder {
+	b.message = message
+	return b
+}
+
+// Code overrides the HTTP status code, like WithCode.
+func (b *Builder) Code(code int) *Builder {
+	b.options = append(b.options, WithCode(code))
+	return b
+}
+
+// Internal sets the internal error, like WithInternalError.
+func (b *Builder) Internal(err error) *Builder {
+	b.options = append(b.options, WithInternalError(err))
+	return b
+}
+
+// Metadata adds a metadata entry, like WithMetadata.
+func (b *Builder) Metadata(key string, value any) *Builder {
+	b.options = append(b.options, WithMetadata(key, value))
+	return b
+}
+
+// TraceID sets the trace ID, like WithTraceID.
+func (b *Builder) TraceID(id string) *Builder {
+	b.options = append(b.options, WithTraceID(id))
+	return b
+}
+
+// Build creates the ApiError. The builder can be reused; each call returns a new error.
+func (b *Builder) Build() *ApiError {
+	return newApiError(b.errorType, b.message, append([]ErrorOption(nil), b.options...))
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	// Arrange
+	internalErr := errors.New("no rows in result set")
+
+	// Act
+	apiError := NewBuilder(NotFoundErrorType).
+		Message("User not found").
+		Code(http.StatusGone).
+		Internal(internalErr).
+		Metadata("user_id", 42).
+		TraceID("4bf92f3577b34da6").
+		Build()
+
+	// Assert
+	if apiError.ErrorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, apiError.ErrorType)
+	}
+	if apiError.Message != "User not found" {
+		t.Errorf("expected message %s, got %s", "User not found", apiError.Message)
+	}
+	if apiError.ErrorCode != http.StatusGone {
+		t.Errorf("expected error code %d, got %d", http.StatusGone, apiError.ErrorCode)
+	}
+	if apiError.InnerError != internalErr {
+		t.Errorf("expected inner error %v, got %v", internalErr, apiError.InnerError)
+	}
+	if apiError.Metadata["user_id"] != 42 {
+		t.Errorf("expected metadata user_id %d, got %v", 42, apiError.Metadata["user_id"])
+	}
+	if apiError.TraceID != "4bf92f3577b34da6" {
+		t.Errorf("expected trace id %s, got %s", "4bf92f3577b34da6", apiError.TraceID)
+	}
+}
+
+func TestBuilderDefaultsMessageFromRegistry(t *testing.T) {
+	apiError := NewBuilder(ConflictErrorType).Build()
+
+	if apiError.Message != "Conflict occurred" {
+		t.Errorf("expected message %s, got %s", "Conflict occurred", apiError.Message)
+	}
+}
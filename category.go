@@ -0,0 +1,39 @@
+package errors
+
+import "net/http"
+
+// Category groups error types into coarse classes for dashboards and alerting.
+type Category string
+
+const (
+	CategoryAuth       Category = "auth"
+	CategoryValidation Category = "validation"
+	CategoryNotFound   Category = "not_found"
+	CategoryRateLimit  Category = "rate_limit"
+	CategoryServer     Category = "server"
+	CategoryOther      Category = "other"
+)
+
+// Category returns the category registered for the error type with WithCategory,
+// otherwise it is derived from the error code: 401 and 403 are CategoryAuth, 400 and
+// 422 CategoryValidation, 404 CategoryNotFound, 429 CategoryRateLimit and 5xx
+// CategoryServer. Anything else is CategoryOther.
+func (e *ApiError) Category() Category {
+	if errType, exists := LookupErrorType(e.ErrorType); exists && errType.Category != "" {
+		return errType.Category
+	}
+	switch {
+	case e.ErrorCode == http.StatusUnauthorized || e.ErrorCode == http.StatusForbidden:
+		return CategoryAuth
+	case e.ErrorCode == http.StatusBadRequest || e.ErrorCode == http.StatusUnprocessableEntity:
+		return CategoryValidation
+	case e.ErrorCode == http.StatusNotFound:
+		return CategoryNotFound
+	case e.ErrorCode == http.StatusTooManyRequests:
+		return CategoryRateLimit
+	case e.IsServerError():
+		return CategoryServer
+	default:
+		return CategoryOther
+	}
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected Category
+	}{
+		{"unauthorized", Unauthorized("Who are you").ApiError, CategoryAuth},
+		{"forbidden", Forbidden("Not yours").ApiError, CategoryAuth},
+		{"bad request", BadRequest("Invalid id").ApiError, CategoryValidation},
+		{"unprocessable entity", UnprocessableEntity("Invalid email").ApiError, CategoryValidation},
+		{"not found", NotFound("User not found").ApiError, CategoryNotFound},
+		{"too many requests", TooManyRequests("Slow down").ApiError, CategoryRateLimit},
+		{"internal server", InternalServer("boom").ApiError, CategoryServer},
+		{"generic", NewApiError("UnknownError", "Oops"), CategoryServer},
+		{"custom 5xx", NewApiError("UnknownError", "Upstream down", WithCode(http.StatusBadGateway)), CategoryServer},
+		{"conflict", Conflict("Taken").ApiError, CategoryOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if category := tt.apiError.Category(); category != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, category)
+			}
+		})
+	}
+}
+
+func TestCategoryFromRegistration(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("SessionExpiredError", http.StatusGone, "Session expired", WithCategory(CategoryAuth))
+
+	if category := NewApiError("SessionExpiredError", "").Category(); category != CategoryAuth {
+		t.Errorf("expected %s, got %s", CategoryAuth, category)
+	}
+}
@@ -0,0 +1,90 @@
+package errors
+
+import "errors"
+
+// ErrCycleDetected is passed to Walk callbacks, and ends the result of Chain, when an
+// inner error leads back to an ApiError already visited.
+var ErrCycleDetected = errors.New("cycle detected")
+
+// visitedErrors records the ApiErrors seen while following an inner error chain.
+type visitedErrors []*ApiError
+
+// revisit reports whether err is an ApiError that was seen before, recording it otherwise.
+func (v *visitedErrors) revisit(err error) bool {
+	apiError, ok := baseApiError(err)
+	if !ok {
+		return false
+	}
+	for _, seen := range *v {
+		if seen == apiError {
+			return true
+		}
+	}
+	*v = append(*v, apiError)
+	return false
+}
+
+// Cause returns the root cause of the error by following the inner error chain to
+// the deepest error. It returns the ApiError itself when it wraps nothing.
+func (e *ApiError) Cause() error {
+	if e == nil {
+		return nil
+	}
+	return Cause(e)
+}
+
+// Cause returns the root cause of err, compatible with github.com/pkg/errors. It follows
+// ApiError inner errors and single-error Unwrap methods, stopping at the first error that
+// does not wrap another, or at the last error before the chain leads back to an ApiError
+// already visited.
+func Cause(err error) error {
+	var visited visitedErrors
+	visited.revisit(err)
+	for err != nil {
+		next := unwrapInner(err)
+		if next == nil || visited.revisit(next) {
+			return err
+		}
+		err = next
+	}
+	return nil
+}
+
+// unwrapInner follows an ApiError to its internal error and any other error through errors.Unwrap.
+func unwrapInner(err error) error {
+	if apiError, ok := baseApiError(err); ok {
+		return apiError.InnerError
+	}
+	return errors.Unwrap(err)
+}
+
+// Chain returns every error from e down to its root cause, following inner errors and
+// single-error Unwrap methods. The first element is e itself. If the chain leads back to
+// an ApiError already listed, it ends with ErrCycleDetected instead.
+func (e *ApiError) Chain() []error {
+	var chain []error
+	e.Walk(func(err error) bool {
+		chain = append(chain, err)
+		return true
+	})
+	return chain
+}
+
+// Walk calls fn for each error in e's chain, starting with e, until fn returns false.
+// If the chain leads back to an ApiError already visited, fn is called once more with
+// ErrCycleDetected and the walk stops.
+func (e *ApiError) Walk(fn func(error) bool) {
+	if e == nil {
+		return
+	}
+	var visited visitedErrors
+	for err := error(e); err != nil; err = unwrapInner(err) {
+		if visited.revisit(err) {
+			fn(ErrCycleDetected)
+			return
+		}
+		if !fn(err) {
+			return
+		}
+	}
+}
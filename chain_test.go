@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCauseReturnsDeepestError(t *testing.T) {
+	// Arrange: ApiError -> fmt wrap -> ApiError -> root error
+	rootErr := errors.New("connection refused")
+	inner := NotFound("User not found", WithInternalError(rootErr))
+	apiError := InternalServer("boom", WithInternalError(fmt.Errorf("load user: %w", inner)))
+
+	// Assert
+	if apiError.Cause() != rootErr {
+		t.Errorf("expected cause %v, got %v", rootErr, apiError.Cause())
+	}
+	if Cause(fmt.Errorf("handler: %w", apiError)) != rootErr {
+		t.Errorf("expected cause %v, got %v", rootErr, Cause(apiError))
+	}
+}
+
+func TestCauseWithoutInnerError(t *testing.T) {
+	apiError := NotFound("User not found")
+
+	if apiError.Cause() != apiError.ApiError {
+		t.Errorf("expected cause to be the ApiError itself, got %v", apiError.Cause())
+	}
+	if Cause(nil) != nil {
+		t.Errorf("expected nil cause for nil, got %v", Cause(nil))
+	}
+}
+
+func TestChain(t *testing.T) {
+	// Arrange: ApiError -> ApiError -> root error
+	rootErr := errors.New("connection refused")
+	inner := NotFound("User not found", WithInternalError(rootErr))
+	apiError := InternalServer("boom", WithInternalError(inner))
+
+	// Act
+	chain := apiError.Chain()
+
+	// Assert
+	expected := []error{apiError.ApiError, inner, rootErr}
+	if len(chain) != len(expected) {
+		t.Fatalf("expected %d errors, got %d", len(expected), len(chain))
+	}
+	for i := range expected {
+		if chain[i] != expected[i] {
+			t.Errorf("expected error %d to be %v, got %v", i, expected[i], chain[i])
+		}
+	}
+}
+
+func TestWalkStopsEarly(t *testing.T) {
+	inner := NotFound("User not found", WithInternalError(errors.New("connection refused")))
+	apiError := InternalServer("boom", WithInternalError(inner))
+
+	var visited []error
+	apiError.Walk(func(err error) bool {
+		visited = append(visited, err)
+		return !IsNotFound(err)
+	})
+
+	if len(visited) != 2 || visited[1] != inner {
+		t.Errorf("expected walk to stop at %v, visited %v", inner, visited)
+	}
+}
+
+func TestChainOfNilApiError(t *testing.T) {
+	var apiError *ApiError
+
+	if chain := apiError.Chain(); chain != nil {
+		t.Errorf("expected nil chain, got %v", chain)
+	}
+}
+
+func TestChainStopsAtCycle(t *testing.T) {
+	// Arrange: outer -> fmt wrap -> inner -> outer
+	inner := NotFound("User not found")
+	outer := InternalServer("boom", WithInternalError(fmt.Errorf("load user: %w", inner)))
+	inner.InnerError = outer
+
+	// Act
+	chain := outer.Chain()
+
+	// Assert
+	if len(chain) != 4 {
+		t.Fatalf("expected 4 errors in chain, got %d: %v", len(chain), chain)
+	}
+	if chain[0] != outer.ApiError || chain[2] != inner {
+		t.Errorf("expected chain to visit outer and inner once, got %v", chain)
+	}
+	if chain[3] != ErrCycleDetected {
+		t.Errorf("expected chain to end with %v, got %v", ErrCycleDetected, chain[3])
+	}
+}
+
+func TestCauseStopsAtCycle(t *testing.T) {
+	apiError := NotFound("User not found")
+	apiError.InnerError = apiError
+
+	if apiError.Cause() != apiError.ApiError {
+		t.Errorf("expected cause to be the ApiError itself, got %v", apiError.Cause())
+	}
+
+	inner := NotFound("User not found")
+	outer := InternalServer("boom", WithInternalError(inner))
+	inner.InnerError = outer
+
+	if Cause(outer) != inner {
+		t.Errorf("expected cause %v, got %v", inner, Cause(outer))
+	}
+}
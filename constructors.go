@@ -0,0 +1,51 @@
+package errors
+
+// NotFound creates a NotFoundError ApiError.
+func NotFound(message string, options ...ErrorOption) *NotFoundError {
+	return &NotFoundError{newApiError(NotFoundErrorType, message, options)}
+}
+
+// InternalServer creates an InternalServerError ApiError.
+func InternalServer(message string, options ...ErrorOption) *InternalServerError {
+	return &InternalServerError{newApiError(InternalServerErrorType, message, options)}
+}
+
+// BadRequest creates a BadRequestError ApiError.
+func BadRequest(message string, options ...ErrorOption) *BadRequestError {
+	return &BadRequestError{newApiError(BadRequestErrorType, message, options)}
+}
+
+// Unauthorized creates an UnauthorizedError ApiError.
+func Unauthorized(message string, options ...ErrorOption) *UnauthorizedError {
+	return &UnauthorizedError{newApiError(UnauthorizedErrorType, message, options)}
+}
+
+// Forbidden creates a ForbiddenError ApiError.
+func Forbidden(message string, options ...ErrorOption) *ForbiddenError {
+	return &ForbiddenError{newApiError(ForbiddenErrorType, message, options)}
+}
+
+// Conflict creates a ConflictError ApiError.
+func Conflict(message string, options ...ErrorOption) *ConflictError {
+	return &ConflictError{newApiError(ConflictErrorType, message, options)}
+}
+
+// MethodNotAllowed creates a MethodNotAllowedError ApiError.
+func MethodNotAllowed(message string, options ...ErrorOption) *MethodNotAllowedError {
+	return &MethodNotAllowedError{newApiError(MethodNotAllowedErrorType, message, options)}
+}
+
+// RequestTimeout creates a RequestTimeoutError ApiError.
+func RequestTimeout(message string, options ...ErrorOption) *RequestTimeoutError {
+	return &RequestTimeoutError{newApiError(RequestTimeoutErrorType, message, options)}
+}
+
+// UnprocessableEntity creates an UnprocessableEntityError ApiError.
+func UnprocessableEntity(message string, options ...ErrorOption) *UnprocessableEntityError {
+	return &UnprocessableEntityError{newApiError(UnprocessableEntityErrorType, message, options)}
+}
+
+// TooManyRequests creates a TooManyRequestsError ApiError.
+func TooManyRequests(message string, options ...ErrorOption) *TooManyRequestsError {
+	return &TooManyRequestsError{newApiError(TooManyRequestsErrorType, message, options)}
+}
@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestConvenienceConstructors(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiError     *ApiError
+		expectedType string
+		expectedCode int
+	}{
+		{"NotFound", NotFound("something happened").ApiError, NotFoundErrorType, http.StatusNotFound},
+		{"InternalServer", InternalServer("something happened").ApiError, InternalServerErrorType, http.StatusInternalServerError},
+		{"BadRequest", BadRequest("something happened").ApiError, BadRequestErrorType, http.StatusBadRequest},
+		{"Unauthorized", Unauthorized("something happened").ApiError, UnauthorizedErrorType, http.StatusUnauthorized},
+		{"Forbidden", Forbidden("something happened").ApiError, ForbiddenErrorType, http.StatusForbidden},
+		{"Conflict", Conflict("something happened").ApiError, ConflictErrorType, http.StatusConflict},
+		{"MethodNotAllowed", MethodNotAllowed("something happened").ApiError, MethodNotAllowedErrorType, http.StatusMethodNotAllowed},
+		{"RequestTimeout", RequestTimeout("something happened").ApiError, RequestTimeoutErrorType, http.StatusRequestTimeout},
+		{"UnprocessableEntity", UnprocessableEntity("something happened").ApiError, UnprocessableEntityErrorType, http.StatusUnprocessableEntity},
+		{"TooManyRequests", TooManyRequests("something happened").ApiError, TooManyRequestsErrorType, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiError := tt.apiError
+
+			if apiError.ErrorType != tt.expectedType {
+				t.Errorf("expected error type %s, got %s", tt.expectedType, apiError.ErrorType)
+			}
+			if apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, apiError.ErrorCode)
+			}
+			if apiError.Message != "something happened" {
+				t.Errorf("expected message %s, got %s", "something happened", apiError.Message)
+			}
+		})
+	}
+}
+
+func TestConvenienceConstructorForwardsOptions(t *testing.T) {
+	internalErr := errors.New("record not found")
+	apiError := NotFound("User not found", WithInternalError(internalErr))
+
+	if apiError.InnerError != internalErr {
+		t.Errorf("expected internal error %v, got %v", internalErr, apiError.InnerError)
+	}
+}
@@ -0,0 +1,60 @@
+package errors
+
+import "context"
+
+// ContextKey is the type of the context keys exposed by this package.
+type ContextKey string
+
+// TraceIDContextKey is the context key WithTraceIDFromContext reads a string trace ID from.
+const TraceIDContextKey ContextKey = "diabuddy-errors.trace_id"
+
+// ContextWithTraceID returns a copy of ctx carrying the trace ID under TraceIDContextKey.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, TraceIDContextKey, traceID)
+}
+
+// WithTraceID correlates the error with a request or trace ID.
+func WithTraceID(id string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.TraceID = id
+	}
+}
+
+// WithTraceIDFromContext sets the trace ID stored in ctx under TraceIDContextKey, if any.
+func WithTraceIDFromContext(ctx context.Context) ErrorOption {
+	return func(ae *ApiError) {
+		if traceID, ok := ctx.Value(TraceIDContextKey).(string); ok && traceID != "" {
+			ae.TraceID = traceID
+		}
+	}
+}
+
+// apiErrorContextKey is the context key IntoContext stores an ApiError under.
+type apiErrorContextKey struct{}
+
+// IntoContext returns a copy of ctx carrying err, so middleware can hand it to later handlers.
+func IntoContext(ctx context.Context, err *ApiError) context.Context {
+	return context.WithValue(ctx, apiErrorContextKey{}, err)
+}
+
+// FromContext returns the ApiError stored by IntoContext, if any.
+func FromContext(ctx context.Context) (*ApiError, bool) {
+	err, ok := ctx.Value(apiErrorContextKey{}).(*ApiError)
+	return err, ok && err != nil
+}
+
+// verboseErrorsContextKey is the context key ContextWithVerboseErrors stores the verbosity under.
+type verboseErrorsContextKey struct{}
+
+// ContextWithVerboseErrors returns a copy of ctx that makes MarshalJSONContext include
+// internal details when verbose is true, for example on an internal staff API, and
+// leave them out when it is false, for example on a public API.
+func ContextWithVerboseErrors(ctx context.Context, verbose bool) context.Context {
+	return context.WithValue(ctx, verboseErrorsContextKey{}, verbose)
+}
+
+// verboseErrorsFromContext returns the verbosity stored by ContextWithVerboseErrors, if any.
+func verboseErrorsFromContext(ctx context.Context) (bool, bool) {
+	verbose, ok := ctx.Value(verboseErrorsContextKey{}).(bool)
+	return verbose, ok
+}
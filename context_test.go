@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithTraceID(t *testing.T) {
+	apiError := NotFound("User not found", WithTraceID("4bf92f3577b34da6"))
+
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	expectedJSON := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"trace_id":"4bf92f3577b34da6","status_text":"Not Found"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+}
+
+func TestWithTraceIDFromContext(t *testing.T) {
+	ctx := ContextWithTraceID(context.Background(), "4bf92f3577b34da6")
+
+	apiError := NotFound("User not found", WithTraceIDFromContext(ctx))
+
+	if apiError.TraceID != "4bf92f3577b34da6" {
+		t.Errorf("expected trace ID %s, got %s", "4bf92f3577b34da6", apiError.TraceID)
+	}
+}
+
+func TestWithTraceIDFromContextWithoutTraceID(t *testing.T) {
+	apiError := NotFound("User not found", WithTraceID("existing"), WithTraceIDFromContext(context.Background()))
+
+	if apiError.TraceID != "existing" {
+		t.Errorf("expected trace ID %s, got %s", "existing", apiError.TraceID)
+	}
+}
+
+func TestIntoAndFromContext(t *testing.T) {
+	apiError := NotFound("User not found")
+	ctx := IntoContext(context.Background(), apiError.ApiError)
+
+	stored, ok := FromContext(ctx)
+	if !ok {
+		t.Fatal("expected an ApiError in the context")
+	}
+	if stored != apiError.ApiError {
+		t.Errorf("expected %v, got %v", apiError, stored)
+	}
+}
+
+func TestFromEmptyContext(t *testing.T) {
+	if apiError, ok := FromContext(context.Background()); ok {
+		t.Errorf("expected no ApiError in an empty context, got %v", apiError)
+	}
+	if apiError, ok := FromContext(IntoContext(context.Background(), nil)); ok {
+		t.Errorf("expected no ApiError for a stored nil, got %v", apiError)
+	}
+}
+
+func TestMarshalJSONContext(t *testing.T) {
+	apiError := InternalServer("Internal server error",
+		WithInternalError(NotFound("User not found", WithMetadata("user_id", 42))),
+		WithMetadata("region", "eu"),
+	)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectedJSON string
+	}{
+		{"verbose", ContextWithVerboseErrors(context.Background(), true), `{"internal_error":{"error_type":"NotFoundError","message":"User not found","error_code":404,"metadata":{"user_id":42},"status_text":"Not Found"},"error_type":"InternalServerError","message":"Internal server error","error_code":500,"metadata":{"region":"eu"},"status_text":"Internal Server Error"}`},
+		{"redacted", ContextWithVerboseErrors(context.Background(), false), `{"error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`},
+		{"package settings", context.Background(), `{"internal_error":{"error_type":"NotFoundError","message":"User not found","error_code":404,"metadata":{"user_id":42},"status_text":"Not Found"},"error_type":"InternalServerError","message":"Internal server error","error_code":500,"metadata":{"region":"eu"},"status_text":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			jsonData, err := apiError.MarshalJSONContext(tt.ctx)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+
+			// Assert
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+		})
+	}
+}
+
+func TestMarshalJSONContextVerboseIncludesStack(t *testing.T) {
+	apiError := InternalServer("Internal server error", WithStackTrace())
+
+	jsonData, err := apiError.MarshalJSONContext(ContextWithVerboseErrors(context.Background(), true))
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	if !strings.Contains(string(jsonData), `"stack":[`) {
+		t.Errorf("expected a stack in %s", jsonData)
+	}
+	if apiError.Metadata != nil {
+		t.Errorf("expected the ApiError to be unchanged, got metadata %v", apiError.Metadata)
+	}
+}
+
+func TestMarshalJSONContextRedactsChildMetadata(t *testing.T) {
+	apiError := Join(UnprocessableEntityErrorType, "Validation failed", BadRequest("Invalid name", WithMetadata("field", "name")))
+
+	jsonData, err := apiError.MarshalJSONContext(ContextWithVerboseErrors(context.Background(), false))
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	if strings.Contains(string(jsonData), "metadata") {
+		t.Errorf("expected no metadata in %s", jsonData)
+	}
+}
@@ -0,0 +1,35 @@
+//go:build echo
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EchoErrorHandler is an echo.HTTPErrorHandler that writes err with WriteError. Errors
+// raised by Echo itself, such as an unknown route, are converted by status code; any
+// other error without an ApiError in its chain is reported as an InternalServerError.
+func EchoErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	var apiError *ApiError
+	var httpError *echo.HTTPError
+	if !errors.As(err, &apiError) && errors.As(err, &httpError) {
+		err = fromEchoHTTPError(httpError)
+	}
+	WriteError(c.Response(), err)
+}
+
+// fromEchoHTTPError converts an echo.HTTPError to the ApiError registered for its code.
+func fromEchoHTTPError(httpError *echo.HTTPError) *ApiError {
+	message := fmt.Sprint(httpError.Message)
+	options := []ErrorOption{WithInternalError(httpError.Internal)}
+	if errorType, exists := ErrorTypeForCode(httpError.Code); exists {
+		return NewApiError(errorType, message, options...)
+	}
+	return NewApiError("", message, append(options, WithCode(httpError.Code))...)
+}
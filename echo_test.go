@@ -0,0 +1,62 @@
+//go:build echo
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestEchoErrorHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{"api error", NotFound("User not found"), http.StatusNotFound, `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"echo error", echo.NewHTTPError(http.StatusMethodNotAllowed, "Method Not Allowed"), http.StatusMethodNotAllowed, `{"error_type":"MethodNotAllowedError","message":"Method Not Allowed","error_code":405,"status_text":"Method Not Allowed"}`},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, `{"internal_error":"boom","error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			e := echo.New()
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/users/1", nil), recorder)
+
+			// Act
+			EchoErrorHandler(tt.err, c)
+
+			// Assert
+			if recorder.Code != tt.expectedCode {
+				t.Errorf("expected status %d, got %d", tt.expectedCode, recorder.Code)
+			}
+			if body := recorder.Body.String(); body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestEchoErrorHandlerAsServerHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = EchoErrorHandler
+	e.GET("/users/:id", func(echo.Context) error {
+		return Conflict("Email already taken")
+	})
+	recorder := httptest.NewRecorder()
+
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected content type %s, got %s", "application/json", contentType)
+	}
+}
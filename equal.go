@@ -0,0 +1,40 @@
+package errors
+
+import "errors"
+
+// Equal reports whether a and b describe the same error: the same type, code and message,
+// and inner errors with the same message. Stack traces, timestamps and other details are
+// ignored. Two nil errors are equal; a nil and a non-nil error are not.
+func Equal(a, b *ApiError) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ErrorType == b.ErrorType &&
+		a.ErrorCode == b.ErrorCode &&
+		a.message() == b.message() &&
+		innerErrorMessage(a.InnerError) == innerErrorMessage(b.InnerError)
+}
+
+// MatchesType reports whether err has an ApiError of errorType in its chain, regardless
+// of its message. It is meant for test assertions that do not care about the text.
+func MatchesType(err error, errorType string) bool {
+	return isErrorType(err, errorType)
+}
+
+// MatchesCode reports whether err has an ApiError in its chain with the given code,
+// regardless of its type and message.
+func MatchesCode(err error, code int) bool {
+	var apiError *ApiError
+	if !errors.As(err, &apiError) || apiError == nil {
+		return false
+	}
+	return apiError.ErrorCode == code
+}
+
+// innerErrorMessage returns the message of err, or "" if err is nil.
+func innerErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
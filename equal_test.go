@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *ApiError
+		b        *ApiError
+		expected bool
+	}{
+		{"same fields", NotFound("User not found").ApiError, NotFound("User not found").ApiError, true},
+		{"same inner error message", NotFound("User not found", WithInternalError(errors.New("no rows"))).ApiError, NotFound("User not found", WithInternalError(errors.New("no rows"))).ApiError, true},
+		{"ignores stack trace", NotFound("User not found", WithStackTrace()).ApiError, NotFound("User not found").ApiError, true},
+		{"ignores timestamp", NotFound("User not found", WithNow()).ApiError, NotFound("User not found").ApiError, true},
+		{"different message", NotFound("User not found").ApiError, NotFound("Order not found").ApiError, false},
+		{"different type", NotFound("Oops").ApiError, Conflict("Oops").ApiError, false},
+		{"different code", NotFound("Oops").ApiError, NotFound("Oops", WithCode(410)).ApiError, false},
+		{"different inner error", NotFound("Oops", WithInternalError(errors.New("a"))).ApiError, NotFound("Oops", WithInternalError(errors.New("b"))).ApiError, false},
+		{"missing inner error", NotFound("Oops", WithInternalError(errors.New("a"))).ApiError, NotFound("Oops").ApiError, false},
+		{"both nil", nil, nil, true},
+		{"left nil", nil, NotFound("Oops").ApiError, false},
+		{"right nil", NotFound("Oops").ApiError, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatchesType(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		errorType string
+		expected  bool
+	}{
+		{"same type", NotFound("User not found"), NotFoundErrorType, true},
+		{"ignores message", NotFound("Order not found"), NotFoundErrorType, true},
+		{"wrapped", fmt.Errorf("load user: %w", NotFound("User not found")), NotFoundErrorType, true},
+		{"different type", Conflict("Oops"), NotFoundErrorType, false},
+		{"plain error", errors.New("no rows"), NotFoundErrorType, false},
+		{"nil", nil, NotFoundErrorType, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesType(tt.err, tt.errorType); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatchesCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     int
+		expected bool
+	}{
+		{"same code", NotFound("User not found"), http.StatusNotFound, true},
+		{"overridden code", NotFound("User not found", WithCode(http.StatusGone)), http.StatusGone, true},
+		{"wrapped", fmt.Errorf("load user: %w", NotFound("User not found")), http.StatusNotFound, true},
+		{"different code", Conflict("Oops"), http.StatusNotFound, false},
+		{"plain error", errors.New("no rows"), http.StatusNotFound, false},
+		{"nil", nil, http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesCode(tt.err, tt.code); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
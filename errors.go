@@ -1,24 +1,45 @@
 package errors
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 )
 
 const (
-	NotFoundErrorType            = "NotFoundError"
-	InternalServerErrorType      = "InternalServerError"
-	BadRequestErrorType          = "BadRequestError"
-	UnauthorizedErrorType        = "UnauthorizedError"
-	ForbiddenErrorType           = "ForbiddenError"
-	ConflictErrorType            = "ConflictError"
-	MethodNotAllowedErrorType    = "MethodNotAllowedError"
-	RequestTimeoutErrorType      = "RequestTimeoutError"
-	UnprocessableEntityErrorType = "UnprocessableEntityError"
-	TooManyRequestsErrorType     = "TooManyRequestsError"
+	NotFoundErrorType                   = "NotFoundError"
+	InternalServerErrorType             = "InternalServerError"
+	BadRequestErrorType                 = "BadRequestError"
+	UnauthorizedErrorType               = "UnauthorizedError"
+	ForbiddenErrorType                  = "ForbiddenError"
+	ConflictErrorType                   = "ConflictError"
+	MethodNotAllowedErrorType           = "MethodNotAllowedError"
+	RequestTimeoutErrorType             = "RequestTimeoutError"
+	UnprocessableEntityErrorType        = "UnprocessableEntityError"
+	TooManyRequestsErrorType            = "TooManyRequestsError"
+	ClientClosedRequestErrorType        = "ClientClosedRequestError"
+	MovedPermanentlyErrorType           = "MovedPermanentlyError"
+	FoundErrorType                      = "FoundError"
+	PaymentRequiredErrorType            = "PaymentRequiredError"
+	GoneErrorType                       = "GoneError"
+	UnsupportedMediaTypeErrorType       = "UnsupportedMediaTypeError"
+	UnavailableForLegalReasonsErrorType = "UnavailableForLegalReasonsError"
+	GenericErrorType                    = "GenericError"
 )
 
+// StatusClientClosedRequest is the non-standard status used when the client cancels the request.
+const StatusClientClosedRequest = 499
+
+// genericErrorMessage is the default message for errors of an unknown type.
+const genericErrorMessage = "An unexpected error occurred"
+
 type ErrorOption func(*ApiError)
 
 type ApiErrors interface {
@@ -31,10 +52,40 @@ type ApiErrors interface {
 
 // ApiError represents a structured error for the API.
 type ApiError struct {
-	ErrorType  string `json:"error_type"`
-	Message    string `json:"message"`
-	ErrorCode  int    `json:"error_code"`
-	InnerError error  `json:"-"`
+	ErrorType string `json:"error_type"`
+	// Message is the user-facing message. It is empty for errors created with
+	// NewApiErrorLazyf until set explicitly; read it with UserMessage to cover both.
+	Message         string         `json:"message"`
+	Details         []string       `json:"details,omitempty"`
+	ErrorCode       int            `json:"error_code"`
+	ApplicationCode string         `json:"code,omitempty"`
+	HelpURL         string         `json:"help_url,omitempty"`
+	Metadata        map[string]any `json:"metadata,omitempty"`
+	TraceID         string         `json:"trace_id,omitempty"`
+	InnerError      error          `json:"-"`
+	// InnerErrors are secondary internal errors that add context to InnerError.
+	InnerErrors []error       `json:"-"`
+	Errors      []error       `json:"-"`
+	RetryAfter  time.Duration `json:"-"`
+	Timestamp   time.Time     `json:"-"`
+	Instance    string        `json:"-"`
+	Location    string        `json:"-"`
+	// DeveloperMessage is a developer-only explanation, written only while internal errors are not redacted.
+	DeveloperMessage string `json:"-"`
+	// Extra keeps JSON fields this package does not know about, so they survive a round-trip.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	stack       []uintptr
+	severity    Severity
+	retryable   *bool
+	lazyMessage *lazyMessage
+	// typeUnknown, appCodeSet and helpURLSet record, while options are applied, whether the
+	// requested type was unknown and whether ApplicationCode and HelpURL were set explicitly.
+	typeUnknown bool
+	appCodeSet  bool
+	helpURLSet  bool
+	// sanitized marks a copy made by Sanitize, which never encodes its internal errors.
+	sanitized bool
 }
 
 // make sure ApiError implements ApiErrors interface in compile time
@@ -42,52 +93,344 @@ var _ ApiErrors = (*ApiError)(nil)
 
 // Type return ApiError Type.
 func (e *ApiError) Type() string {
+	if e == nil {
+		return ""
+	}
 	return e.ErrorType
 }
 
+// UserMessage returns the user-facing message: Message, or for errors created with
+// NewApiErrorLazyf, the formatted message while Message is empty.
+func (e *ApiError) UserMessage() string {
+	if e == nil {
+		return ""
+	}
+	return e.message()
+}
+
 // Code return ApiError code.
+//
+// Code is kept for backward compatibility and is the same as StatusCode. Prefer
+// StatusCode, as the application code such as "USER_NOT_FOUND" lives in AppCode.
 func (e *ApiError) Code() int {
+	return e.StatusCode()
+}
+
+// StatusCode returns the HTTP status code of the error, or 0 for a nil ApiError.
+func (e *ApiError) StatusCode() int {
+	if e == nil {
+		return 0
+	}
 	return e.ErrorCode
 }
 
-// Error implements the error interface for ApiError
+// AppCode returns the application code of the error, such as "USER_NOT_FOUND", or ""
+// for a nil ApiError.
+func (e *ApiError) AppCode() string {
+	if e == nil {
+		return ""
+	}
+	return e.ApplicationCode
+}
+
+// Error implements the error interface for ApiError. The inner error is appended after
+// ": " while SetIncludeInnerInError is enabled, stopping with "(cycle detected)" if the
+// inner errors lead back to an ApiError already written. A nil ApiError returns "<nil>".
 func (e *ApiError) Error() string {
-	return fmt.Sprintf("Error %d: %s", e.ErrorCode, e.Message)
+	if e == nil {
+		return "<nil>"
+	}
+	// Built with append rather than fmt.Sprintf so the common case allocates only the result.
+	var buf [128]byte
+	b := append(buf[:0], "Error "...)
+	b = strconv.AppendInt(b, int64(e.ErrorCode), 10)
+	b = append(b, ": "...)
+	b = append(b, e.message()...)
+	if e.InnerError != nil && includeInnerInError.Load() {
+		b = e.appendInnerErrors(b)
+	}
+	return string(b)
+}
+
+// appendInnerErrors appends ": " and the message of each inner error in turn, as Error
+// does for nested ApiErrors, ending with "(cycle detected)" if the chain leads back to an
+// ApiError already written.
+func (e *ApiError) appendInnerErrors(b []byte) []byte {
+	visited := visitedErrors{e}
+	for err := e.InnerError; err != nil; {
+		b = append(b, ": "...)
+		if visited.revisit(err) {
+			return append(b, "(cycle detected)"...)
+		}
+		inner, ok := baseApiError(err)
+		if !ok || inner == nil {
+			return append(b, err.Error()...)
+		}
+		b = append(b, "Error "...)
+		b = strconv.AppendInt(b, int64(inner.ErrorCode), 10)
+		b = append(b, ": "...)
+		b = append(b, inner.message()...)
+		err = inner.InnerError
+	}
+	return b
 }
 
 // HTTPError generates an HTTP error response
 func (e *ApiError) HTTPError() (int, string) {
-	return e.ErrorCode, e.Message
+	if e == nil {
+		return 0, ""
+	}
+	return e.ErrorCode, e.message()
 }
 
 // InternalError return ApiError message.
 func (e *ApiError) InternalError() error {
+	if e == nil {
+		return nil
+	}
 	return e.InnerError
 }
 
-// MarshalJSON customizes the JSON serialization for ApiError.
+// Unwrap returns the internal error. When secondary internal errors or joined child
+// errors are set too, it returns them all joined with errors.Join, so errors.Is and
+// errors.As still reach every branch.
+func (e *ApiError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	if len(e.InnerErrors) == 0 && len(e.Errors) == 0 {
+		return e.InnerError
+	}
+	errs := make([]error, 0, 1+len(e.InnerErrors)+len(e.Errors))
+	errs = append(errs, e.InnerError)
+	errs = append(errs, e.InnerErrors...)
+	return errors.Join(append(errs, e.Errors...)...)
+}
+
+// Is reports whether target is an ApiError or sentinel error of the same ErrorType, regardless of message.
+// A nil ApiError matches nothing.
+func (e *ApiError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	if t, ok := target.(*sentinel); ok {
+		return e.ErrorType == t.errorType
+	}
+	t, ok := baseApiError(target)
+	return ok && t != nil && e.ErrorType == t.ErrorType
+}
+
+// Clone returns a copy of the ApiError whose metadata map, child errors, stack and
+// overrides are copied too, so changing the copy never affects the original.
+// Metadata values and wrapped errors themselves are shared. A nil ApiError clones to nil.
+func (e *ApiError) Clone() *ApiError {
+	if e == nil {
+		return nil
+	}
+	clone := *e
+	if e.Metadata != nil {
+		clone.Metadata = make(map[string]any, len(e.Metadata))
+		for key, value := range e.Metadata {
+			clone.Metadata[key] = value
+		}
+	}
+	if e.Errors != nil {
+		clone.Errors = append([]error(nil), e.Errors...)
+	}
+	if e.InnerErrors != nil {
+		clone.InnerErrors = append([]error(nil), e.InnerErrors...)
+	}
+	if e.stack != nil {
+		clone.stack = append([]uintptr(nil), e.stack...)
+	}
+	if e.retryable != nil {
+		retryable := *e.retryable
+		clone.retryable = &retryable
+	}
+	if e.Details != nil {
+		clone.Details = append([]string(nil), e.Details...)
+	}
+	if e.Extra != nil {
+		clone.Extra = make(map[string]json.RawMessage, len(e.Extra))
+		for key, value := range e.Extra {
+			clone.Extra[key] = value
+		}
+	}
+	return &clone
+}
+
+// Sanitize returns a copy of a server error (5xx) whose Message is replaced by the
+// registry's default message for its type. The original error is kept as InnerError for
+// logging, but the copy never encodes its internal errors or developer message, whatever
+// the redaction settings. Client errors (4xx) are assumed safe to display and are
+// returned unchanged.
+func (e *ApiError) Sanitize() *ApiError {
+	if e == nil || !e.IsServerError() {
+		return e
+	}
+	message := genericErrorMessage
+	if errType, exists := LookupErrorType(e.ErrorType); exists && errType.Message != "" {
+		message = errType.Message
+	}
+	sanitized := e.Clone()
+	sanitized.Message = message
+	sanitized.InnerError = e
+	sanitized.sanitized = true
+	return sanitized
+}
+
+// MarshalJSON customizes the JSON serialization for ApiError. Inner errors are nested as
+// objects when they are ApiErrors and written as their message otherwise; they and the
+// developer message are left out while internal errors are redacted. The stack, the
+// format of error_code and the key naming style follow the package settings. Unknown
+// fields kept in Extra are appended last, and a nil ApiError marshals to null.
 func (e *ApiError) MarshalJSON() ([]byte, error) {
-	type Alias ApiError // Create an alias to avoid recursion
-	var internalErrorStr string
-	if e.InnerError != nil {
-		internalErrorStr = e.InnerError.Error()
+	return e.marshalJSON(defaultMarshalConfig())
+}
+
+// MarshalJSONContext is like MarshalJSON but honors the verbosity set on ctx with
+// ContextWithVerboseErrors: verbose output includes the inner error, the developer
+// message, the captured stack and the metadata, while redacted output leaves them out.
+// Without a verbosity on ctx the package settings apply, as in MarshalJSON.
+func (e *ApiError) MarshalJSONContext(ctx context.Context) ([]byte, error) {
+	config := defaultMarshalConfig()
+	if verbose, ok := verboseErrorsFromContext(ctx); ok {
+		config = marshalConfig{internalError: verbose, stack: verbose, metadata: verbose}
+	}
+	return e.marshalJSON(config)
+}
+
+// marshalConfig selects the optional parts of the JSON of an ApiError.
+type marshalConfig struct {
+	internalError bool
+	stack         bool
+	metadata      bool
+}
+
+// defaultMarshalConfig returns the marshalConfig that follows the package settings.
+func defaultMarshalConfig() marshalConfig {
+	return marshalConfig{
+		internalError: !redactInternalErrors.Load(),
+		stack:         includeStackInJSON.Load(),
+		metadata:      true,
 	}
+}
 
-	return json.Marshal(&struct {
-		InternalError string `json:"internal_error,omitempty"`
+// marshalJSON encodes the ApiError, including the optional parts selected by config.
+// Nested ApiErrors are encoded with the same config.
+func (e *ApiError) marshalJSON(config marshalConfig) ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	type Alias ApiError // Create an alias to avoid recursion
+	includeInternal := config.internalError && !e.sanitized
+	var internalError any
+	if includeInternal {
+		var err error
+		if internalError, err = marshalInnerError(e.InnerError, config); err != nil {
+			return nil, err
+		}
+	}
+	var internalErrors []any
+	if includeInternal {
+		for _, err := range e.InnerErrors {
+			internalErr, marshalErr := marshalInnerError(err, config)
+			if marshalErr != nil {
+				return nil, marshalErr
+			}
+			internalErrors = append(internalErrors, internalErr)
+		}
+	}
+	var developerMessage string
+	if includeInternal {
+		developerMessage = e.DeveloperMessage
+	}
+	var timestamp string
+	if !e.Timestamp.IsZero() {
+		timestamp = e.Timestamp.Format(time.RFC3339)
+	}
+	var stack []string
+	if config.stack {
+		stack = e.stackFrames()
+	}
+	var childErrors []any
+	for _, err := range e.Errors {
+		childError, marshalErr := marshalInnerError(err, config)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		childErrors = append(childErrors, childError)
+	}
+	fields := e
+	if (!config.metadata && e.Metadata != nil) || e.lazyMessage != nil {
+		resolved := *e
+		resolved.Message = e.message()
+		if !config.metadata {
+			resolved.Metadata = nil
+		}
+		fields = &resolved
+	}
+	data, err := json.Marshal(&struct {
+		InternalError  any   `json:"internal_error,omitempty"`
+		InternalErrors []any `json:"internal_errors,omitempty"`
 		*Alias
+		DeveloperMessage  string   `json:"developer_message,omitempty"`
+		StatusText        string   `json:"status_text,omitempty"`
+		RetryAfterSeconds int      `json:"retry_after_seconds,omitempty"`
+		Timestamp         string   `json:"timestamp,omitempty"`
+		Stack             []string `json:"stack,omitempty"`
+		Errors            []any    `json:"errors,omitempty"`
 	}{
-		InternalError: internalErrorStr,
-		Alias:         (*Alias)(e),
+		InternalError:     internalError,
+		InternalErrors:    internalErrors,
+		Alias:             (*Alias)(fields),
+		DeveloperMessage:  developerMessage,
+		StatusText:        http.StatusText(e.ErrorCode),
+		RetryAfterSeconds: e.retryAfterSeconds(),
+		Timestamp:         timestamp,
+		Stack:             stack,
+		Errors:            childErrors,
 	})
+	if err != nil {
+		return nil, err
+	}
+	if internalError == nil && alwaysEmitInternalError.Load() {
+		if data, err = prependJSONField(data, "internal_error", nil); err != nil {
+			return nil, err
+		}
+	}
+	if data, err = e.appendExtra(data); err != nil {
+		return nil, err
+	}
+	if data, err = applyCodeFormat(data); err != nil {
+		return nil, err
+	}
+	return applyNamingStyle(data)
 }
 
 // UnmarshalJSON customizes the JSON deserialization for ApiError.
+// Keys are expected in the style set with SetJSONNamingStyle. A missing or zero
+// error_code is resolved from the registry, defaulting to 500 for unknown types; it may
+// be given as a number or a numeric string.
+// Unknown fields are kept in Extra. While SetStrictUnmarshal is enabled, an error_type
+// missing from the registry is rejected with ErrUnknownErrorType.
 func (e *ApiError) UnmarshalJSON(data []byte) error {
+	data, err := normalizeNamingStyle(data)
+	if err != nil {
+		return err
+	}
+	if data, err = normalizeCodeFormat(data); err != nil {
+		return err
+	}
 	type Alias ApiError
 	aux := &struct {
-		InternalError *string `json:"internal_error,omitempty"`
+		InternalError  json.RawMessage   `json:"internal_error,omitempty"`
+		InternalErrors []json.RawMessage `json:"internal_errors,omitempty"`
 		*Alias
+		DeveloperMessage  string            `json:"developer_message,omitempty"`
+		RetryAfterSeconds int               `json:"retry_after_seconds,omitempty"`
+		Timestamp         string            `json:"timestamp,omitempty"`
+		Errors            []json.RawMessage `json:"errors,omitempty"`
 	}{
 		Alias: (*Alias)(e),
 	}
@@ -95,57 +438,174 @@ func (e *ApiError) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
+	if strictUnmarshal.Load() {
+		if _, exists := LookupErrorType(e.ErrorType); !exists {
+			return fmt.Errorf("%w: %q", ErrUnknownErrorType, e.ErrorType)
+		}
+	}
 
-	if aux.InternalError != nil {
-		e.InnerError = fmt.Errorf(*aux.InternalError)
+	if e.ErrorCode == 0 {
+		e.ErrorCode = http.StatusInternalServerError
+		if errType, exists := LookupErrorType(e.ErrorType); exists {
+			e.ErrorCode = errType.ErrorCode
+		}
+	}
+
+	innerError, err := unmarshalInnerError(aux.InternalError)
+	if err != nil {
+		return err
+	}
+	if innerError != nil {
+		e.InnerError = innerError
+	}
+	for _, raw := range aux.InternalErrors {
+		internalErr, err := unmarshalInnerError(raw)
+		if err != nil {
+			return err
+		}
+		if internalErr != nil {
+			e.InnerErrors = append(e.InnerErrors, internalErr)
+		}
+	}
+	if aux.DeveloperMessage != "" {
+		e.DeveloperMessage = aux.DeveloperMessage
+	}
+	if aux.RetryAfterSeconds > 0 {
+		e.RetryAfter = time.Duration(aux.RetryAfterSeconds) * time.Second
+	}
+	if aux.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, aux.Timestamp)
+		if err != nil {
+			return err
+		}
+		e.Timestamp = timestamp
+	}
+	if e.Extra, err = unknownJSONFields(data); err != nil {
+		return err
+	}
+	for _, raw := range aux.Errors {
+		childError, err := unmarshalInnerError(raw)
+		if err != nil {
+			return err
+		}
+		if childError != nil {
+			e.Errors = append(e.Errors, childError)
+		}
 	}
 	return nil
 }
 
-// ErrorType represents an error type configuration
-type ErrorType struct {
-	ErrorCode int
-	Message   string
+// marshalInnerError returns the JSON value of an inner error: the ApiError encoded
+// with config so it nests as an object, or the error message for any other error.
+func marshalInnerError(err error, config marshalConfig) (any, error) {
+	if err == nil {
+		return nil, nil
+	}
+	if apiError, ok := baseApiError(err); ok {
+		data, marshalErr := apiError.marshalJSON(config)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		return json.RawMessage(data), nil
+	}
+	return err.Error(), nil
 }
 
-// ErrorRegistry is a map of error types and their properties
-var ErrorRegistry = map[string]ErrorType{
-	NotFoundErrorType:            {http.StatusNotFound, "Resource not found"},
-	InternalServerErrorType:      {http.StatusInternalServerError, "Internal server error"},
-	BadRequestErrorType:          {http.StatusBadRequest, "Bad request"},
-	UnauthorizedErrorType:        {http.StatusUnauthorized, "Unauthorized access"},
-	ForbiddenErrorType:           {http.StatusForbidden, "Forbidden"},
-	ConflictErrorType:            {http.StatusConflict, "Conflict occurred"},
-	MethodNotAllowedErrorType:    {http.StatusMethodNotAllowed, "Method not allowed"},
-	RequestTimeoutErrorType:      {http.StatusRequestTimeout, "Request timed out"},
-	UnprocessableEntityErrorType: {http.StatusUnprocessableEntity, "Unprocessable entity"},
-	TooManyRequestsErrorType:     {http.StatusTooManyRequests, "Too many requests"},
-	// You can add more error types as needed...
+// unmarshalInnerError rebuilds an inner error from its JSON value, restoring
+// nested objects as *ApiError and strings as plain errors.
+func unmarshalInnerError(data json.RawMessage) (error, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if data[0] == '{' {
+		apiError := &ApiError{}
+		if err := json.Unmarshal(data, apiError); err != nil {
+			return nil, err
+		}
+		return apiError, nil
+	}
+	var message string
+	if err := json.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+	return errors.New(message), nil
 }
 
 // NewApiError creates a new ApiError based on the error type.
+//
+// The message is resolved in this order: a non-empty userMessage wins, then a
+// message set by WithMessage, then the registry's default message for the type.
+// Line breaks and other control characters are removed from the message.
+// Unknown types fall back to the type set with SetDefaultErrorType, GenericError by default.
 func NewApiError(errorType string, userMessage string, options ...ErrorOption) *ApiError {
-	apiError := &ApiError{
-		ErrorType: "GenericError",
-		Message:   userMessage,
-		ErrorCode: http.StatusInternalServerError,
+	return newApiError(errorType, userMessage, options)
+}
+
+// NewApiErrorf creates a new ApiError whose message is formatted with fmt.Sprintf.
+func NewApiErrorf(errorType string, format string, args ...any) *ApiError {
+	return newApiError(errorType, fmt.Sprintf(format, args...), nil)
+}
+
+// New is like NewApiError but returns the error interface. Use it where an error is
+// expected, so that a nil *ApiError is never stored in an error variable, where it
+// would compare as non-nil.
+func New(errorType string, message string, options ...ErrorOption) error {
+	return newApiError(errorType, message, options)
+}
+
+// newApiError builds an ApiError. Exported constructors must call it directly so
+// that WithStackTrace can skip a fixed number of frames.
+func newApiError(errorType string, userMessage string, options []ErrorOption) *ApiError {
+	errType, exists := LookupErrorType(errorType)
+	if !exists {
+		errorType, errType = defaultErrorType()
 	}
-	if errType, exists := ErrorRegistry[errorType]; exists {
-		apiError = &ApiError{
-			ErrorType: errorType,
-			Message:   userMessage,
-			ErrorCode: errType.ErrorCode,
-		}
+	apiError := &ApiError{
+		ErrorType:       errorType,
+		Message:         userMessage,
+		ErrorCode:       errType.ErrorCode,
+		ApplicationCode: errType.AppCode,
+		HelpURL:         errType.HelpURL,
+		typeUnknown:     !exists,
 	}
 	for _, option := range options {
 		option(apiError)
 	}
+	// An option such as WithCause may have changed the type; take its defaults unless
+	// an option set them explicitly.
+	if apiError.ErrorType != errorType {
+		if changed, exists := LookupErrorType(apiError.ErrorType); exists {
+			if !apiError.appCodeSet {
+				apiError.ApplicationCode = changed.AppCode
+			}
+			if !apiError.helpURLSet {
+				apiError.HelpURL = changed.HelpURL
+			}
+			errType = changed
+		}
+	}
+	if apiError.Message == "" && apiError.lazyMessage == nil {
+		apiError.Message = errType.Message
+	}
+	apiError.Message = stripControlChars(apiError.Message)
 	return apiError
 }
 
-// RegisterErrorType Optional: Method to add new error types to the registry at runtime
-func RegisterErrorType(name string, errorCode int, message string) {
-	ErrorRegistry[name] = ErrorType{errorCode, message}
+// stripControlChars replaces line breaks and tabs with a space and drops any other
+// control characters, so a message cannot break log lines or HTTP headers.
+func stripControlChars(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		default:
+			return r
+		}
+	}, s)
 }
 
 // WithInternalError to wrap internal errors
@@ -154,3 +614,141 @@ func WithInternalError(err error) ErrorOption {
 		ae.InnerError = err
 	}
 }
+
+// WithInternalErrorf sets an inner error built with fmt.Errorf, so a %w verb keeps the
+// wrapped error reachable through errors.Is and errors.As.
+func WithInternalErrorf(format string, args ...any) ErrorOption {
+	return WithInternalError(fmt.Errorf(format, args...))
+}
+
+// WithInternalErrors sets the first non-nil error as the inner error and keeps the rest
+// as secondary InnerErrors, for context such as a failed rollback after a failed write.
+func WithInternalErrors(errs ...error) ErrorOption {
+	return func(ae *ApiError) {
+		var secondary []error
+		for _, err := range errs {
+			switch {
+			case err == nil:
+			case ae.InnerError == nil:
+				ae.InnerError = err
+			default:
+				secondary = append(secondary, err)
+			}
+		}
+		ae.InnerErrors = secondary
+	}
+}
+
+// WithCause sets err as the inner error. If the error was created with an unknown type,
+// which falls back to the default type (see SetDefaultErrorType), and err has an ApiError
+// in its chain, the type and code of that ApiError are adopted, along with the default
+// message, AppCode and HelpURL of its type, unless options set them explicitly.
+func WithCause(err error) ErrorOption {
+	return func(ae *ApiError) {
+		ae.InnerError = err
+		var cause *ApiError
+		if ae.typeUnknown && errors.As(err, &cause) && cause != nil {
+			ae.ErrorType = cause.ErrorType
+			ae.ErrorCode = cause.ErrorCode
+			ae.typeUnknown = false
+		}
+	}
+}
+
+// WithLocation sets the redirect target that WriteError sends in the Location header.
+func WithLocation(url string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.Location = url
+	}
+}
+
+// WithDeveloperMessage sets a developer-only message that is kept out of redacted output.
+func WithDeveloperMessage(message string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.DeveloperMessage = message
+	}
+}
+
+// WithAppCode sets the application-specific error code, overriding the registered default.
+func WithAppCode(code string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.ApplicationCode = code
+		ae.appCodeSet = true
+	}
+}
+
+// WithHelpURL sets a link to documentation about the error, overriding the registered default.
+func WithHelpURL(url string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.HelpURL = url
+		ae.helpURLSet = true
+	}
+}
+
+// WithDetail appends a human-readable detail that adds to the message.
+func WithDetail(detail string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.Details = append(ae.Details, detail)
+	}
+}
+
+// WithMessage sets the message used when NewApiError is called with an empty message.
+func WithMessage(msg string) ErrorOption {
+	return func(ae *ApiError) {
+		if ae.Message == "" {
+			ae.Message = msg
+		}
+	}
+}
+
+// WithMessagef is like WithMessage but formats the message with fmt.Sprintf.
+func WithMessagef(format string, args ...any) ErrorOption {
+	return WithMessage(fmt.Sprintf(format, args...))
+}
+
+// WithCode overrides the HTTP status code resolved from the registry.
+func WithCode(code int) ErrorOption {
+	return func(ae *ApiError) {
+		ae.ErrorCode = code
+	}
+}
+
+// WithMetadata attaches a machine-readable key/value pair; pass it several times for multiple keys.
+func WithMetadata(key string, value any) ErrorOption {
+	return func(ae *ApiError) {
+		if ae.Metadata == nil {
+			ae.Metadata = make(map[string]any)
+		}
+		ae.Metadata[key] = value
+	}
+}
+
+// WithRetryAfter sets how long clients should wait before retrying, typically for TooManyRequestsError.
+func WithRetryAfter(d time.Duration) ErrorOption {
+	return func(ae *ApiError) {
+		ae.RetryAfter = d
+	}
+}
+
+// retryAfterSeconds returns RetryAfter rounded up to whole seconds, as used by the Retry-After header.
+func (e *ApiError) retryAfterSeconds() int {
+	if e.RetryAfter <= 0 {
+		return 0
+	}
+	return int((e.RetryAfter + time.Second - 1) / time.Second)
+}
+
+// WithTimestamp records when the error occurred; it is serialized as RFC 3339.
+func WithTimestamp(t time.Time) ErrorOption {
+	return func(ae *ApiError) {
+		ae.Timestamp = t
+	}
+}
+
+// WithNow records the current time, as reported by the clock set with SetClock, as the
+// moment the error occurred.
+func WithNow() ErrorOption {
+	return func(ae *ApiError) {
+		ae.Timestamp = now().UTC()
+	}
+}
@@ -1,11 +1,14 @@
 package errors
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestNewApiError checks if the error is correctly created
@@ -60,7 +63,7 @@ func TestMarshalJSON(t *testing.T) {
 	}
 
 	// Assert: Check if the JSON contains expected fields
-	expectedJSON := `{"error_type":"NotFoundError","message":"User not found","error_code":404}`
+	expectedJSON := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`
 	if string(jsonData) != expectedJSON {
 		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
 	}
@@ -76,7 +79,7 @@ func TestMarshalJSONWithInnerError(t *testing.T) {
 	}
 
 	// Assert: Check if the JSON contains expected fields
-	expectedJSON := `{"internal_error":"test internal error","error_type":"NotFoundError","message":"User not found","error_code":404}`
+	expectedJSON := `{"internal_error":"test internal error","error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`
 	if string(jsonData) != expectedJSON {
 		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
 	}
@@ -192,3 +195,968 @@ func TestApiError_HttPError(t *testing.T) {
 		t.Errorf("Expected error message '%s', but got '%s'", "User not found", message)
 	}
 }
+
+func TestApiError_UnwrapWithoutInnerError(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found")
+	if errors.Unwrap(apiError) != nil {
+		t.Errorf("expected nil unwrapped error, got %v", errors.Unwrap(apiError))
+	}
+}
+
+func TestApiError_UnwrapReturnsInnerError(t *testing.T) {
+	internalErr := errors.New("database connection failed")
+	apiError := NewApiError(InternalServerErrorType, "Internal server error", WithInternalError(internalErr))
+	if errors.Unwrap(apiError) != internalErr {
+		t.Errorf("expected unwrapped error %v, got %v", internalErr, errors.Unwrap(apiError))
+	}
+}
+
+func TestApiError_IsWalksMultiLevelChain(t *testing.T) {
+	// Arrange: root cause -> fmt wrap -> ApiError -> ApiError
+	rootErr := errors.New("no rows in result set")
+	wrapped := fmt.Errorf("query user: %w", rootErr)
+	inner := NewApiError(NotFoundErrorType, "User not found", WithInternalError(wrapped))
+	outer := NewApiError(InternalServerErrorType, "Internal server error", WithInternalError(inner))
+
+	// Assert
+	if !errors.Is(outer, rootErr) {
+		t.Error("expected errors.Is to find the root error in the chain")
+	}
+	if !errors.Is(outer, &ApiError{ErrorType: NotFoundErrorType}) {
+		t.Error("expected errors.Is to find the NotFound ApiError in the chain")
+	}
+	var target *ApiError
+	if errors.As(wrapped, &target) {
+		t.Error("expected errors.As not to find an ApiError in a plain error chain")
+	}
+}
+
+func TestApiError_IsMatchesByType(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found")
+	if !errors.Is(apiError, &ApiError{ErrorType: NotFoundErrorType}) {
+		t.Error("expected errors.Is to match an ApiError with the same type")
+	}
+	if errors.Is(apiError, &ApiError{ErrorType: BadRequestErrorType}) {
+		t.Error("expected errors.Is not to match an ApiError with a different type")
+	}
+	if errors.Is(apiError, errors.New("User not found")) {
+		t.Error("expected errors.Is not to match a plain error")
+	}
+}
+
+func TestApiError_AsExtractsWrappedApiError(t *testing.T) {
+	apiError := NewApiError(ConflictErrorType, "Email already taken")
+	err := fmt.Errorf("create user: %w", apiError)
+
+	var target *ApiError
+	if !errors.As(err, &target) {
+		t.Fatal("expected errors.As to extract the ApiError")
+	}
+	if target.ErrorCode != http.StatusConflict {
+		t.Errorf("expected error code %d, got %d", http.StatusConflict, target.ErrorCode)
+	}
+}
+
+func TestNewApiErrorFallsBackToRegistryMessage(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "")
+	if apiError.Message != "Resource not found" {
+		t.Errorf("expected message %s, got %s", "Resource not found", apiError.Message)
+	}
+}
+
+func TestWithMessageIsUsedWhenMessageIsEmpty(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "", WithMessage("User not found"))
+	if apiError.Message != "User not found" {
+		t.Errorf("expected message %s, got %s", "User not found", apiError.Message)
+	}
+}
+
+func TestExplicitMessageWinsOverWithMessage(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "Order not found", WithMessage("User not found"))
+	if apiError.Message != "Order not found" {
+		t.Errorf("expected message %s, got %s", "Order not found", apiError.Message)
+	}
+}
+
+func TestWithCodeOverridesRegistryCode(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "gone", WithCode(http.StatusGone))
+
+	if apiError.Code() != http.StatusGone {
+		t.Errorf("expected error code %d, got %d", http.StatusGone, apiError.Code())
+	}
+	if apiError.Type() != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, apiError.Type())
+	}
+}
+
+func TestWithCodeLaterOptionWins(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "gone", WithCode(http.StatusGone), WithCode(http.StatusTeapot))
+
+	if apiError.Code() != http.StatusTeapot {
+		t.Errorf("expected error code %d, got %d", http.StatusTeapot, apiError.Code())
+	}
+}
+
+func TestWithMetadataRoundTrip(t *testing.T) {
+	// Arrange: Create an ApiError with two metadata keys
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithMetadata("resource", "user"), WithMetadata("id", 42))
+
+	// Act: Marshal the ApiError to JSON
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	// Assert: Check the metadata is serialized
+	expectedJSON := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"metadata":{"id":42,"resource":"user"},"status_text":"Not Found"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+
+	// Act: Unmarshal it back
+	var decoded ApiError
+	if err := json.Unmarshal(jsonData, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	// Assert: Check the metadata map is restored
+	if decoded.Metadata["resource"] != "user" {
+		t.Errorf("expected metadata resource %s, got %v", "user", decoded.Metadata["resource"])
+	}
+	if decoded.Metadata["id"] != float64(42) {
+		t.Errorf("expected metadata id %d, got %v", 42, decoded.Metadata["id"])
+	}
+}
+
+func TestMarshalJSONWithNestedApiError(t *testing.T) {
+	// Arrange: wrap a NotFound ApiError in an InternalServer ApiError
+	inner := NewApiError(NotFoundErrorType, "User not found", WithInternalError(errors.New("no rows")))
+	apiError := NewApiError(InternalServerErrorType, "Internal server error", WithInternalError(inner))
+
+	// Act
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	// Assert: the inner ApiError is nested as an object
+	expectedJSON := `{"internal_error":{"internal_error":"no rows","error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"},"error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+}
+
+func TestUnmarshalJSONWithNestedApiError(t *testing.T) {
+	// Arrange
+	jsonStr := `{"internal_error":{"internal_error":"no rows","error_type":"NotFoundError","message":"User not found","error_code":404},"error_type":"InternalServerError","message":"Internal server error","error_code":500}`
+
+	// Act
+	var apiError ApiError
+	if err := json.Unmarshal([]byte(jsonStr), &apiError); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	// Assert: the nested object is rebuilt as an ApiError
+	inner, ok := apiError.InnerError.(*ApiError)
+	if !ok {
+		t.Fatalf("expected inner error to be *ApiError, got %T", apiError.InnerError)
+	}
+	if inner.ErrorType != NotFoundErrorType {
+		t.Errorf("expected inner error type %s, got %s", NotFoundErrorType, inner.ErrorType)
+	}
+	if inner.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected inner error code %d, got %d", http.StatusNotFound, inner.ErrorCode)
+	}
+	if inner.InnerError == nil || inner.InnerError.Error() != "no rows" {
+		t.Errorf("expected inner internal error %s, got %v", "no rows", inner.InnerError)
+	}
+}
+
+func TestWithRetryAfterRoundTrip(t *testing.T) {
+	apiError := NewApiError(TooManyRequestsErrorType, "Slow down", WithRetryAfter(30*time.Second))
+
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	expectedJSON := `{"error_type":"TooManyRequestsError","message":"Slow down","error_code":429,"status_text":"Too Many Requests","retry_after_seconds":30}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+
+	var decoded ApiError
+	if err := json.Unmarshal(jsonData, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if decoded.RetryAfter != 30*time.Second {
+		t.Errorf("expected retry after %s, got %s", 30*time.Second, decoded.RetryAfter)
+	}
+}
+
+func TestNewApiErrorf(t *testing.T) {
+	apiError := NewApiErrorf(NotFoundErrorType, "user %d not found", 42)
+
+	if apiError.Message != "user 42 not found" {
+		t.Errorf("expected message %s, got %s", "user 42 not found", apiError.Message)
+	}
+	if apiError.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, apiError.ErrorCode)
+	}
+}
+
+func TestWithMessagef(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "", WithMessagef("order %s not found", "A-17"))
+
+	if apiError.Message != "order A-17 not found" {
+		t.Errorf("expected message %s, got %s", "order A-17 not found", apiError.Message)
+	}
+}
+
+func TestCloneCopiesMetadata(t *testing.T) {
+	// Arrange
+	original := NewApiError(NotFoundErrorType, "User not found", WithMetadata("id", 42), WithRetryable(false))
+
+	// Act: clone and mutate the clone
+	clone := original.Clone()
+	clone.Metadata["id"] = 7
+	clone.Metadata["resource"] = "user"
+	clone.Message = "Order not found"
+	*clone.retryable = true
+
+	// Assert: the original is unchanged
+	if original.Metadata["id"] != 42 {
+		t.Errorf("expected original metadata id %d, got %v", 42, original.Metadata["id"])
+	}
+	if _, exists := original.Metadata["resource"]; exists {
+		t.Error("expected original metadata not to contain resource")
+	}
+	if original.Message != "User not found" {
+		t.Errorf("expected original message %s, got %s", "User not found", original.Message)
+	}
+	if original.IsRetryable() {
+		t.Error("expected original retryable override to be unchanged")
+	}
+}
+
+func TestNilApiErrorReceiver(t *testing.T) {
+	var apiError *ApiError
+
+	if apiError.Error() != "<nil>" {
+		t.Errorf("expected Error %s, got %s", "<nil>", apiError.Error())
+	}
+	if apiError.Type() != "" {
+		t.Errorf("expected empty Type, got %s", apiError.Type())
+	}
+	if apiError.Code() != 0 {
+		t.Errorf("expected Code 0, got %d", apiError.Code())
+	}
+	if code, message := apiError.HTTPError(); code != 0 || message != "" {
+		t.Errorf("expected zero HTTPError, got %d %s", code, message)
+	}
+	if apiError.InternalError() != nil {
+		t.Errorf("expected nil InternalError, got %v", apiError.InternalError())
+	}
+	if apiError.Unwrap() != nil {
+		t.Errorf("expected nil Unwrap, got %v", apiError.Unwrap())
+	}
+	jsonData, err := apiError.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal nil ApiError: %v", err)
+	}
+	if string(jsonData) != "null" {
+		t.Errorf("expected %s, got %s", "null", string(jsonData))
+	}
+}
+
+func TestNilApiErrorThroughErrorInterface(t *testing.T) {
+	var apiError *ApiError
+	var err error = apiError
+
+	if err.Error() != "<nil>" {
+		t.Errorf("expected Error %s, got %s", "<nil>", err.Error())
+	}
+	if got := fmt.Sprintf("%+v", err); got != "<nil>" {
+		t.Errorf("expected %s, got %s", "<nil>", got)
+	}
+}
+
+func TestNewApiErrorDefaultMessageForKnownType(t *testing.T) {
+	apiError := NewApiError(BadRequestErrorType, "")
+	if apiError.Message != "Bad request" {
+		t.Errorf("expected message %s, got %s", "Bad request", apiError.Message)
+	}
+}
+
+func TestNewApiErrorDefaultMessageForGenericError(t *testing.T) {
+	apiError := NewApiError("UnknownError", "")
+
+	if apiError.ErrorType != "GenericError" {
+		t.Errorf("expected error type %s, got %s", "GenericError", apiError.ErrorType)
+	}
+	if apiError.Message != "An unexpected error occurred" {
+		t.Errorf("expected message %s, got %s", "An unexpected error occurred", apiError.Message)
+	}
+}
+
+func TestWithTimestampRoundTrip(t *testing.T) {
+	// Arrange
+	occurredAt := time.Date(2024, time.March, 14, 9, 26, 53, 0, time.UTC)
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithTimestamp(occurredAt))
+
+	// Act
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	// Assert
+	expectedJSON := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found","timestamp":"2024-03-14T09:26:53Z"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+
+	var decoded ApiError
+	if err := json.Unmarshal(jsonData, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if !decoded.Timestamp.Equal(occurredAt) {
+		t.Errorf("expected timestamp %s, got %s", occurredAt, decoded.Timestamp)
+	}
+}
+
+func TestWithNow(t *testing.T) {
+	before := time.Now()
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithNow())
+
+	if apiError.Timestamp.Before(before.Truncate(time.Second)) || apiError.Timestamp.After(time.Now()) {
+		t.Errorf("expected timestamp close to now, got %s", apiError.Timestamp)
+	}
+}
+
+func TestUnmarshalJSONRejectsInvalidTimestamp(t *testing.T) {
+	var apiError ApiError
+	if err := json.Unmarshal([]byte(`{"error_type":"NotFoundError","timestamp":"yesterday"}`), &apiError); err == nil {
+		t.Error("expected an error for an invalid timestamp")
+	}
+}
+
+func TestUnmarshalJSONResolvesMissingCodeFromRegistry(t *testing.T) {
+	var apiError ApiError
+	if err := json.Unmarshal([]byte(`{"error_type":"ConflictError","message":"Email already taken"}`), &apiError); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if apiError.ErrorCode != http.StatusConflict {
+		t.Errorf("expected error code %d, got %d", http.StatusConflict, apiError.ErrorCode)
+	}
+}
+
+func TestUnmarshalJSONDefaultsUnknownTypeCodeTo500(t *testing.T) {
+	var apiError ApiError
+	if err := json.Unmarshal([]byte(`{"error_type":"MadeUpError","message":"Oops","error_code":0}`), &apiError); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if apiError.ErrorCode != http.StatusInternalServerError {
+		t.Errorf("expected error code %d, got %d", http.StatusInternalServerError, apiError.ErrorCode)
+	}
+}
+
+func TestUnknownJSONFieldsSurviveRoundTrip(t *testing.T) {
+	// Arrange
+	input := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found","hint":"check the id"}`
+
+	// Act
+	var apiError ApiError
+	if err := json.Unmarshal([]byte(input), &apiError); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	data, err := json.Marshal(&apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal JSON: %v", err)
+	}
+
+	// Assert
+	if hint := string(apiError.Extra["hint"]); hint != `"check the id"` {
+		t.Errorf("expected extra hint %s, got %s", `"check the id"`, hint)
+	}
+	if string(data) != input {
+		t.Errorf("expected %s, got %s", input, data)
+	}
+}
+
+func TestMarshalJSONSkipsExtraFieldsShadowingKnownKeys(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found")
+	apiError.Extra = map[string]json.RawMessage{"message": json.RawMessage(`"shadowed"`), "b": json.RawMessage(`2`), "a": json.RawMessage(`1`)}
+
+	data, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal JSON: %v", err)
+	}
+
+	expected := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found","a":1,"b":2}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestAppCode(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("MissingUserError", http.StatusNotFound, "User not found", WithDefaultAppCode("USER_NOT_FOUND"))
+
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"defaults from registry", NewApiError("MissingUserError", ""), `{"error_type":"MissingUserError","message":"User not found","error_code":404,"code":"USER_NOT_FOUND","status_text":"Not Found"}`},
+		{"option overrides default", NewApiError("MissingUserError", "", WithAppCode("ACCOUNT_GONE")), `{"error_type":"MissingUserError","message":"User not found","error_code":404,"code":"ACCOUNT_GONE","status_text":"Not Found"}`},
+		{"omitted when empty", NewApiError(NotFoundErrorType, "User not found"), `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal JSON: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
+
+func TestAppCodeAccessor(t *testing.T) {
+	var nilError *ApiError
+	apiError := NotFound("User not found", WithAppCode("USER_NOT_FOUND"))
+
+	if apiError.AppCode() != "USER_NOT_FOUND" {
+		t.Errorf("expected app code %s, got %s", "USER_NOT_FOUND", apiError.AppCode())
+	}
+	if nilError.AppCode() != "" {
+		t.Errorf("expected empty app code for nil, got %s", nilError.AppCode())
+	}
+}
+
+func TestAppCodeRoundTrip(t *testing.T) {
+	var decoded ApiError
+	if err := json.Unmarshal([]byte(`{"error_type":"NotFoundError","message":"User not found","error_code":404,"code":"USER_NOT_FOUND"}`), &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if decoded.ApplicationCode != "USER_NOT_FOUND" {
+		t.Errorf("expected app code %s, got %s", "USER_NOT_FOUND", decoded.ApplicationCode)
+	}
+	if decoded.Extra != nil {
+		t.Errorf("expected no extra fields, got %v", decoded.Extra)
+	}
+}
+
+func TestHelpURL(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("MissingUserError", http.StatusNotFound, "User not found", WithDefaultHelpURL("https://docs.example.com/errors/missing-user"))
+
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"defaults from registry", NewApiError("MissingUserError", ""), `{"error_type":"MissingUserError","message":"User not found","error_code":404,"help_url":"https://docs.example.com/errors/missing-user","status_text":"Not Found"}`},
+		{"option overrides default", NewApiError("MissingUserError", "", WithHelpURL("https://docs.example.com/users")), `{"error_type":"MissingUserError","message":"User not found","error_code":404,"help_url":"https://docs.example.com/users","status_text":"Not Found"}`},
+		{"omitted when empty", NewApiError(NotFoundErrorType, "User not found"), `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal JSON: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name            string
+		apiError        *ApiError
+		expectedMessage string
+		expectWrapped   bool
+	}{
+		{"server error", InternalServer("pq: relation \"users\" does not exist").ApiError, "Internal server error", true},
+		{"unknown server error type", NewApiError("UnknownError", "open /etc/app/config.yaml: permission denied"), "An unexpected error occurred", true},
+		{"client error", BadRequest("Email is invalid").ApiError, "Email is invalid", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sanitized := tt.apiError.Sanitize()
+
+			// Assert
+			if sanitized.Message != tt.expectedMessage {
+				t.Errorf("expected message %s, got %s", tt.expectedMessage, sanitized.Message)
+			}
+			if tt.expectWrapped && sanitized.InnerError != tt.apiError {
+				t.Errorf("expected inner error %v, got %v", tt.apiError, sanitized.InnerError)
+			}
+			if !tt.expectWrapped && sanitized != tt.apiError {
+				t.Errorf("expected the error to be returned unchanged, got %v", sanitized)
+			}
+		})
+	}
+}
+
+func TestSanitizeKeepsOriginalUnchanged(t *testing.T) {
+	original := InternalServer("pq: connection refused")
+
+	_ = original.Sanitize()
+
+	if original.Message != "pq: connection refused" {
+		t.Errorf("expected message %s, got %s", "pq: connection refused", original.Message)
+	}
+}
+
+func TestSanitizeNeverEncodesOriginal(t *testing.T) {
+	// Arrange
+	original := InternalServer(`pq: relation "users" does not exist`, WithDeveloperMessage("check the migration"))
+
+	// Act
+	sanitized := original.Sanitize()
+	jsonData, err := json.Marshal(sanitized)
+	if err != nil {
+		t.Fatalf("failed to marshal sanitized error: %v", err)
+	}
+	verbose, err := sanitized.MarshalJSONContext(ContextWithVerboseErrors(context.Background(), true))
+	if err != nil {
+		t.Fatalf("failed to marshal sanitized error: %v", err)
+	}
+
+	// Assert
+	expectedJSON := `{"error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+	if strings.Contains(string(verbose), "pq:") {
+		t.Errorf("expected verbose output without the original error, got %s", verbose)
+	}
+	if sanitized.InternalError() != original.ApiError {
+		t.Errorf("expected internal error %v, got %v", original, sanitized.InternalError())
+	}
+}
+
+func TestNewReturnsErrorInterface(t *testing.T) {
+	// Act
+	err := New(NotFoundErrorType, "User not found", WithTraceID("abc123"))
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected a non-nil error")
+	}
+	var apiError *ApiError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("expected errors.As to extract an ApiError from %v", err)
+	}
+	if apiError.ErrorCode != http.StatusNotFound || apiError.TraceID != "abc123" {
+		t.Errorf("expected a 404 with trace id %s, got %d with %s", "abc123", apiError.ErrorCode, apiError.TraceID)
+	}
+}
+
+func TestWithDetailRoundTrip(t *testing.T) {
+	// Arrange
+	apiError := BadRequest("Invalid password", WithDetail("must be at least 12 characters"), WithDetail("must contain a digit"))
+
+	// Act
+	data, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal JSON: %v", err)
+	}
+	var decoded ApiError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	// Assert
+	expected := `{"error_type":"BadRequestError","message":"Invalid password","details":["must be at least 12 characters","must contain a digit"],"error_code":400,"status_text":"Bad Request"}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+	if len(decoded.Details) != 2 || decoded.Details[1] != "must contain a digit" {
+		t.Errorf("expected details %v, got %v", apiError.Details, decoded.Details)
+	}
+}
+
+func TestMarshalJSONStatusText(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"known code", NotFound("User not found").ApiError, `"status_text":"Not Found"`},
+		{"unknown code", NewApiError("UnknownError", "Odd", WithCode(599)), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal JSON: %v", err)
+			}
+			if tt.expected != "" && !strings.Contains(string(data), tt.expected) {
+				t.Errorf("expected %s in %s", tt.expected, data)
+			}
+			if tt.expected == "" && strings.Contains(string(data), "status_text") {
+				t.Errorf("expected no status_text in %s", data)
+			}
+		})
+	}
+}
+
+func TestWithCause(t *testing.T) {
+	plainErr := errors.New("connection refused")
+	notFound := NotFound("User not found")
+
+	tests := []struct {
+		name         string
+		apiError     *ApiError
+		cause        error
+		expectedType string
+		expectedCode int
+	}{
+		{"plain cause", NewApiError("UnknownError", "Lookup failed", WithCause(plainErr)), plainErr, GenericErrorType, http.StatusInternalServerError},
+		{"api error cause", NewApiError("UnknownError", "Lookup failed", WithCause(notFound)), notFound, NotFoundErrorType, http.StatusNotFound},
+		{"wrapped api error cause", NewApiError("UnknownError", "Lookup failed", WithCause(fmt.Errorf("load: %w", notFound))), nil, NotFoundErrorType, http.StatusNotFound},
+		{"typed wrapper keeps its type", Conflict("Lookup failed", WithCause(notFound)).ApiError, notFound, ConflictErrorType, http.StatusConflict},
+		{"later option overrides", NewApiError("UnknownError", "Lookup failed", WithCause(notFound), WithCode(http.StatusGone)), notFound, NotFoundErrorType, http.StatusGone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.apiError.ErrorType != tt.expectedType {
+				t.Errorf("expected error type %s, got %s", tt.expectedType, tt.apiError.ErrorType)
+			}
+			if tt.apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, tt.apiError.ErrorCode)
+			}
+			if tt.cause != nil && tt.apiError.InnerError != tt.cause {
+				t.Errorf("expected inner error %v, got %v", tt.cause, tt.apiError.InnerError)
+			}
+		})
+	}
+}
+
+func TestWithCauseAdoptsTypeDefaults(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("ArchivedError", http.StatusGone, "Resource archived", WithDefaultAppCode("ARCHIVED"), WithDefaultHelpURL("https://docs.example.com/archived"))
+
+	apiError := NewApiError("", "", WithCause(NewApiError("ArchivedError", "Order 7 archived")))
+
+	if apiError.ErrorType != "ArchivedError" || apiError.ErrorCode != http.StatusGone {
+		t.Errorf("expected ArchivedError with code %d, got %s with %d", http.StatusGone, apiError.ErrorType, apiError.ErrorCode)
+	}
+	if apiError.Message != "Resource archived" {
+		t.Errorf("expected message %s, got %s", "Resource archived", apiError.Message)
+	}
+	if apiError.ApplicationCode != "ARCHIVED" || apiError.HelpURL != "https://docs.example.com/archived" {
+		t.Errorf("expected defaults of ArchivedError, got %q and %q", apiError.ApplicationCode, apiError.HelpURL)
+	}
+
+	explicit := NewApiError("", "", WithCause(NewApiError("ArchivedError", "")), WithAppCode("CUSTOM"))
+	if explicit.ApplicationCode != "CUSTOM" {
+		t.Errorf("expected explicit app code %s, got %s", "CUSTOM", explicit.ApplicationCode)
+	}
+
+	// An explicit value equal to the old default is kept too.
+	cleared := NewApiError("", "", WithCause(NewApiError("ArchivedError", "")), WithAppCode(""), WithHelpURL(""))
+	if cleared.ApplicationCode != "" || cleared.HelpURL != "" {
+		t.Errorf("expected explicitly cleared defaults, got %q and %q", cleared.ApplicationCode, cleared.HelpURL)
+	}
+}
+
+func TestWithCauseEmptyMessageUsesCauseTypeMessage(t *testing.T) {
+	apiError := NewApiError("", "", WithCause(NotFound("x")))
+
+	if apiError.ErrorType != NotFoundErrorType || apiError.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected %s with code %d, got %s with %d", NotFoundErrorType, http.StatusNotFound, apiError.ErrorType, apiError.ErrorCode)
+	}
+	if apiError.Message != "Resource not found" {
+		t.Errorf("expected message %s, got %s", "Resource not found", apiError.Message)
+	}
+}
+
+func TestWithCauseWithConfiguredDefaultType(t *testing.T) {
+	t.Cleanup(func() { SetDefaultErrorType("") })
+	SetDefaultErrorType(BadRequestErrorType)
+
+	apiError := NewApiError("UnknownError", "Lookup failed", WithCause(NotFound("User not found")))
+
+	if apiError.ErrorType != NotFoundErrorType || apiError.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected %s with code %d, got %s with %d", NotFoundErrorType, http.StatusNotFound, apiError.ErrorType, apiError.ErrorCode)
+	}
+
+	explicit := NewApiError(BadRequestErrorType, "", WithCause(NotFound("User not found")))
+	if explicit.ErrorType != BadRequestErrorType || explicit.ErrorCode != http.StatusBadRequest {
+		t.Errorf("expected %s with code %d, got %s with %d", BadRequestErrorType, http.StatusBadRequest, explicit.ErrorType, explicit.ErrorCode)
+	}
+}
+
+func TestNewApiErrorStripsControlCharacters(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"newline", BadRequest("Invalid name\nadmin=true").ApiError, "Invalid name admin=true"},
+		{"carriage return and newline", BadRequest("Invalid name\r\nSet-Cookie: x").ApiError, "Invalid name Set-Cookie: x"},
+		{"other control characters", BadRequest("Invalid\x00 name\x1b").ApiError, "Invalid name"},
+		{"message option", BadRequest("", WithMessage("line one\rline two")).ApiError, "line one line two"},
+		{"clean message", BadRequest("Invalid name").ApiError, "Invalid name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.apiError.Message != tt.expected {
+				t.Errorf("expected message %q, got %q", tt.expected, tt.apiError.Message)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONInnerErrorShapes(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectApiErr bool
+		expectedMsg  string
+	}{
+		{"string", `{"internal_error":"no rows","error_type":"NotFoundError"}`, false, "no rows"},
+		{"object", `{"internal_error":{"error_type":"BadRequestError","message":"Invalid id","error_code":400},"error_type":"NotFoundError"}`, true, "Error 400: Invalid id"},
+		{"null", `{"internal_error":null,"error_type":"NotFoundError"}`, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded ApiError
+			if err := json.Unmarshal([]byte(tt.input), &decoded); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+
+			_, isApiError := decoded.InnerError.(*ApiError)
+			if isApiError != tt.expectApiErr {
+				t.Errorf("expected inner ApiError %t, got %T", tt.expectApiErr, decoded.InnerError)
+			}
+			if tt.expectedMsg == "" && decoded.InnerError != nil {
+				t.Errorf("expected no inner error, got %v", decoded.InnerError)
+			}
+			if tt.expectedMsg != "" && (decoded.InnerError == nil || decoded.InnerError.Error() != tt.expectedMsg) {
+				t.Errorf("expected inner error %s, got %v", tt.expectedMsg, decoded.InnerError)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONRejectsInvalidInnerErrorShape(t *testing.T) {
+	var decoded ApiError
+	if err := json.Unmarshal([]byte(`{"internal_error":42,"error_type":"NotFoundError"}`), &decoded); err == nil {
+		t.Error("expected an error for a numeric internal_error")
+	}
+}
+
+func TestStatusCodeMatchesCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected int
+	}{
+		{"registry code", NotFound("User not found", WithAppCode("USER_NOT_FOUND")).ApiError, http.StatusNotFound},
+		{"overridden code", NotFound("User not found", WithCode(http.StatusGone)).ApiError, http.StatusGone},
+		{"nil", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.apiError.StatusCode() != tt.expected {
+				t.Errorf("expected status code %d, got %d", tt.expected, tt.apiError.StatusCode())
+			}
+			if tt.apiError.Code() != tt.apiError.StatusCode() {
+				t.Errorf("expected Code %d to equal StatusCode %d", tt.apiError.Code(), tt.apiError.StatusCode())
+			}
+		})
+	}
+}
+
+func TestDeveloperMessageRoundTrip(t *testing.T) {
+	var decoded ApiError
+	if err := json.Unmarshal([]byte(`{"error_type":"NotFoundError","message":"User not found","developer_message":"no row for id 42"}`), &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if decoded.DeveloperMessage != "no row for id 42" {
+		t.Errorf("expected developer message %s, got %s", "no row for id 42", decoded.DeveloperMessage)
+	}
+	if decoded.Extra != nil {
+		t.Errorf("expected no extra fields, got %v", decoded.Extra)
+	}
+}
+
+func TestErrorMatchesFormattedOutput(t *testing.T) {
+	t.Cleanup(func() { SetIncludeInnerInError(false) })
+
+	tests := []struct {
+		name         string
+		apiError     *ApiError
+		includeInner bool
+		expected     string
+	}{
+		{"common case", NotFound("User not found").ApiError, false, fmt.Sprintf("Error %d: %s", 404, "User not found")},
+		{"teapot code", NotFound("Short and stout", WithCode(http.StatusTeapot)).ApiError, false, fmt.Sprintf("Error %d: %s", 418, "Short and stout")},
+		{"negative code", NotFound("Oops", WithCode(-1)).ApiError, false, fmt.Sprintf("Error %d: %s", -1, "Oops")},
+		{"long message", NotFound(strings.Repeat("x", 300)).ApiError, false, fmt.Sprintf("Error %d: %s", 404, strings.Repeat("x", 300))},
+		{"inner error", NotFound("User not found", WithInternalError(errors.New("no rows"))).ApiError, true, fmt.Sprintf("Error %d: %s: %s", 404, "User not found", "no rows")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetIncludeInnerInError(tt.includeInner)
+
+			if got := tt.apiError.Error(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func BenchmarkError(b *testing.B) {
+	apiError := NotFound("User not found")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = apiError.Error()
+	}
+}
+
+func BenchmarkErrorSprintf(b *testing.B) {
+	apiError := NotFound("User not found")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fmt.Sprintf("Error %d: %s", apiError.ErrorCode, apiError.Message)
+	}
+}
+
+func TestWithInternalErrors(t *testing.T) {
+	// Arrange
+	primary := errors.New("insert order: deadlock detected")
+	rollback := errors.New("rollback: connection reset")
+	audit := NotFound("Audit log missing")
+
+	// Act
+	apiError := InternalServer("Order failed", WithInternalErrors(primary, nil, rollback, audit))
+
+	// Assert
+	if apiError.InnerError != primary {
+		t.Errorf("expected primary inner error %v, got %v", primary, apiError.InnerError)
+	}
+	if len(apiError.InnerErrors) != 2 || apiError.InnerErrors[0] != rollback || apiError.InnerErrors[1] != audit {
+		t.Errorf("expected secondary errors [%v %v], got %v", rollback, audit, apiError.InnerErrors)
+	}
+	for _, err := range []error{primary, rollback, audit} {
+		if !errors.Is(apiError, err) {
+			t.Errorf("expected errors.Is to find %v", err)
+		}
+	}
+	if !errors.Is(apiError, ErrNotFound) {
+		t.Errorf("expected errors.Is to find the secondary NotFound error")
+	}
+}
+
+func TestMarshalJSONWithInternalErrors(t *testing.T) {
+	t.Cleanup(func() { SetRedactInternalErrors(false) })
+	apiError := InternalServer("Order failed", WithInternalErrors(errors.New("deadlock detected"), errors.New("rollback failed"), BadRequest("Invalid id")))
+
+	tests := []struct {
+		name         string
+		redact       bool
+		expectedJSON string
+	}{
+		{"listed without redaction", false, `{"internal_error":"deadlock detected","internal_errors":["rollback failed",{"error_type":"BadRequestError","message":"Invalid id","error_code":400,"status_text":"Bad Request"}],"error_type":"InternalServerError","message":"Order failed","error_code":500,"status_text":"Internal Server Error"}`},
+		{"omitted with redaction", true, `{"error_type":"InternalServerError","message":"Order failed","error_code":500,"status_text":"Internal Server Error"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRedactInternalErrors(tt.redact)
+
+			jsonData, err := json.Marshal(apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONWithInternalErrors(t *testing.T) {
+	input := `{"internal_error":"deadlock detected","internal_errors":["rollback failed",{"error_type":"BadRequestError","message":"Invalid id"}],"error_type":"InternalServerError","message":"Order failed"}`
+
+	var decoded ApiError
+	if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if len(decoded.InnerErrors) != 2 {
+		t.Fatalf("expected 2 secondary errors, got %v", decoded.InnerErrors)
+	}
+	if decoded.InnerErrors[0].Error() != "rollback failed" {
+		t.Errorf("expected %s, got %s", "rollback failed", decoded.InnerErrors[0].Error())
+	}
+	if !IsBadRequest(decoded.InnerErrors[1]) {
+		t.Errorf("expected BadRequest ApiError, got %v", decoded.InnerErrors[1])
+	}
+	if decoded.Extra != nil {
+		t.Errorf("expected no extra fields, got %v", decoded.Extra)
+	}
+}
+
+func TestWithInternalErrorf(t *testing.T) {
+	rootErr := errors.New("connection refused")
+
+	tests := []struct {
+		name            string
+		option          ErrorOption
+		expectedMessage string
+		wrapped         error
+	}{
+		{"plain", WithInternalErrorf("query user %d", 42), "query user 42", nil},
+		{"wrapping", WithInternalErrorf("query user %d: %w", 42, rootErr), "query user 42: connection refused", rootErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiError := InternalServer("Internal server error", tt.option)
+
+			if apiError.InnerError == nil || apiError.InnerError.Error() != tt.expectedMessage {
+				t.Fatalf("expected inner error %s, got %v", tt.expectedMessage, apiError.InnerError)
+			}
+			if tt.wrapped != nil && !errors.Is(apiError, tt.wrapped) {
+				t.Errorf("expected errors.Is to find %v", tt.wrapped)
+			}
+			if tt.wrapped == nil && errors.Unwrap(apiError.InnerError) != nil {
+				t.Errorf("expected inner error to wrap nothing, got %v", errors.Unwrap(apiError.InnerError))
+			}
+		})
+	}
+}
+
+func TestNilApiErrorIsAndClone(t *testing.T) {
+	var apiError *ApiError
+	var err error = apiError
+
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a nil ApiError not to match %v", ErrNotFound)
+	}
+	if errors.Is(err, NotFound("User not found")) {
+		t.Errorf("expected a nil ApiError not to match a NotFound ApiError")
+	}
+	if clone := apiError.Clone(); clone != nil {
+		t.Errorf("expected nil clone, got %#v", clone)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build fiber
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FiberErrorHandler is a fiber.ErrorHandler that responds with err as a JSON ApiError,
+// setting the same headers as WriteError. Errors raised by Fiber itself, such as an
+// unknown route, are converted by status code; any other error without an ApiError in
+// its chain is reported as an InternalServerError.
+func FiberErrorHandler(c *fiber.Ctx, err error) error {
+	var apiError *ApiError
+	var fiberError *fiber.Error
+	if !errors.As(err, &apiError) && errors.As(err, &fiberError) {
+		apiError = fromFiberError(fiberError)
+	} else {
+		apiError = asApiError(err)
+	}
+
+	header := make(http.Header)
+	apiError.WriteHeaders(header)
+	for key := range header {
+		c.Set(key, header.Get(key))
+	}
+	return c.Status(apiError.ErrorCode).JSON(apiError)
+}
+
+// fromFiberError converts a fiber.Error to the ApiError registered for its code.
+func fromFiberError(fiberError *fiber.Error) *ApiError {
+	if errorType, exists := ErrorTypeForCode(fiberError.Code); exists {
+		return NewApiError(errorType, fiberError.Message)
+	}
+	return NewApiError("", fiberError.Message, WithCode(fiberError.Code))
+}
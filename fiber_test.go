@@ -0,0 +1,100 @@
+//go:build fiber
+
+package errors
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestFiberErrorHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{"api error", NotFound("User not found"), http.StatusNotFound, `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"fiber error", fiber.NewError(http.StatusMethodNotAllowed, "Method Not Allowed"), http.StatusMethodNotAllowed, `{"error_type":"MethodNotAllowedError","message":"Method Not Allowed","error_code":405,"status_text":"Method Not Allowed"}`},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, `{"internal_error":"boom","error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			app := fiber.New(fiber.Config{ErrorHandler: FiberErrorHandler})
+			app.Get("/users/:id", func(*fiber.Ctx) error {
+				return tt.err
+			})
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/1", nil))
+			if err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+
+			// Assert
+			if resp.StatusCode != tt.expectedCode {
+				t.Errorf("expected status %d, got %d", tt.expectedCode, resp.StatusCode)
+			}
+			if string(body) != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, string(body))
+			}
+			if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("expected content type application/json, got %s", contentType)
+			}
+		})
+	}
+}
+
+func TestFiberErrorHandlerSetsHeaders(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: FiberErrorHandler})
+	app.Get("/orders", func(*fiber.Ctx) error {
+		return TooManyRequests("Slow down", WithTraceID("4bf92f3577b34da6"), WithRetryAfter(30*time.Second))
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	expected := map[string]string{
+		"X-Error-Type": TooManyRequestsErrorType,
+		"X-Error-Code": "429",
+		"X-Trace-Id":   "4bf92f3577b34da6",
+		"Retry-After":  "30",
+	}
+	for header, value := range expected {
+		if got := resp.Header.Get(header); got != value {
+			t.Errorf("expected %s header %s, got %s", header, value, got)
+		}
+	}
+}
+
+func TestFiberErrorHandlerUnknownRoute(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: FiberErrorHandler})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+	if resp.Header.Get("X-Error-Type") != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, resp.Header.Get("X-Error-Type"))
+	}
+}
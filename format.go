@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter. %v and %s print the compact Error() form, %q
+// quotes it and %#v uses GoString, while %+v expands to the type, code, message,
+// the inner error chain and the captured stack, if any. An inner error chain that leads
+// back to an ApiError already printed ends with "(cycle detected)".
+func (e *ApiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('#') {
+			_, _ = io.WriteString(s, e.GoString())
+			return
+		}
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, e.verbose())
+			return
+		}
+		_, _ = io.WriteString(s, e.Error())
+	case 's':
+		_, _ = io.WriteString(s, e.Error())
+	case 'q':
+		_, _ = io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(*errors.ApiError=%s)", verb, e.Error())
+	}
+}
+
+// GoString implements fmt.GoStringer for %#v, printing the type, code and message
+// without the unexported fields.
+func (e *ApiError) GoString() string {
+	if e == nil {
+		return "(*errors.ApiError)(nil)"
+	}
+	return fmt.Sprintf("errors.ApiError{Type:%q, Code:%d, Message:%q}", e.ErrorType, e.ErrorCode, e.message())
+}
+
+// verbose renders the expanded %+v representation.
+func (e *ApiError) verbose() string {
+	if e == nil {
+		return "<nil>"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d): %s", e.ErrorType, e.ErrorCode, e.message())
+	visited := visitedErrors{e}
+	for err := e.InnerError; err != nil; err = unwrapInner(err) {
+		b.WriteString("\ncaused by: ")
+		if visited.revisit(err) {
+			b.WriteString("(cycle detected)")
+			break
+		}
+		b.WriteString(err.Error())
+	}
+	if frames := e.stackFrames(); len(frames) > 0 {
+		b.WriteString("\nstack:")
+		for _, frame := range frames {
+			b.WriteString("\n\t")
+			b.WriteString(frame)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatCompactVerbs(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithInternalError(errors.New("no rows")))
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "Error 404: User not found"},
+		{"%s", "Error 404: User not found"},
+		{"%q", `"Error 404: User not found"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, apiError); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatPlusVExpandsInnerErrorChain(t *testing.T) {
+	rootErr := errors.New("no rows")
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithInternalError(fmt.Errorf("query user: %w", rootErr)))
+
+	expected := "NotFoundError (404): User not found\ncaused by: query user: no rows\ncaused by: no rows"
+	if got := fmt.Sprintf("%+v", apiError); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestFormatPlusVIncludesStack(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithStackTrace())
+
+	got := fmt.Sprintf("%+v", apiError)
+	if !strings.HasPrefix(got, "NotFoundError (404): User not found\nstack:\n\t") {
+		t.Errorf("expected stack section, got %q", got)
+	}
+	if !strings.Contains(got, "format_test.go") {
+		t.Errorf("expected stack to reference format_test.go, got %q", got)
+	}
+}
+
+func TestGoString(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithStackTrace())
+
+	expected := `errors.ApiError{Type:"NotFoundError", Code:404, Message:"User not found"}`
+	if got := fmt.Sprintf("%#v", apiError); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if got := apiError.GoString(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestGoStringNil(t *testing.T) {
+	var apiError *ApiError
+
+	expected := "(*errors.ApiError)(nil)"
+	if got := fmt.Sprintf("%#v", apiError); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestFormatStopsAtCycle(t *testing.T) {
+	t.Cleanup(func() { SetIncludeInnerInError(false) })
+	inner := NotFound("User not found")
+	outer := InternalServer("boom", WithInternalError(inner))
+	inner.InnerError = outer
+
+	verbose := fmt.Sprintf("%+v", outer)
+	expected := "InternalServerError (500): boom\ncaused by: Error 404: User not found\ncaused by: (cycle detected)"
+	if verbose != expected {
+		t.Errorf("expected %q, got %q", expected, verbose)
+	}
+
+	SetIncludeInnerInError(true)
+	expected = "Error 500: boom: Error 404: User not found: (cycle detected)"
+	if got := fmt.Sprintf("%v", outer); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
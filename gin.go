@@ -0,0 +1,19 @@
+//go:build gin
+
+package errors
+
+import "github.com/gin-gonic/gin"
+
+// AbortWithError aborts the Gin request with err written as a JSON ApiError, using the
+// headers from WriteHeaders, including Retry-After. Errors without an ApiError in their
+// chain are reported as an InternalServerError. The ApiError is also recorded in
+// c.Errors for logging middleware.
+func AbortWithError(c *gin.Context, err error) {
+	if err == nil {
+		return
+	}
+	apiError := asApiError(err)
+	_ = c.Error(apiError)
+	apiError.WriteHeaders(c.Writer.Header())
+	c.AbortWithStatusJSON(apiError.ErrorCode, apiError)
+}
@@ -0,0 +1,76 @@
+//go:build gin
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAbortWithError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{"api error", NotFound("User not found"), http.StatusNotFound, `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, `{"internal_error":"boom","error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var recorded []error
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Next()
+				for _, ginError := range c.Errors {
+					recorded = append(recorded, ginError.Err)
+				}
+			})
+			engine.GET("/users/:id", func(c *gin.Context) {
+				AbortWithError(c, tt.err)
+			})
+			recorder := httptest.NewRecorder()
+
+			// Act
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+			// Assert
+			if recorder.Code != tt.expectedCode {
+				t.Errorf("expected status %d, got %d", tt.expectedCode, recorder.Code)
+			}
+			if body := recorder.Body.String(); body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+			if len(recorded) != 1 || !errors.As(recorded[0], new(*ApiError)) {
+				t.Errorf("expected the ApiError to be recorded on the context, got %v", recorded)
+			}
+		})
+	}
+}
+
+func TestAbortWithErrorSetsRetryAfter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/orders", func(c *gin.Context) {
+		AbortWithError(c, TooManyRequests("Slow down", WithRetryAfter(30*time.Second)))
+	})
+	recorder := httptest.NewRecorder()
+
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != "30" {
+		t.Errorf("expected Retry-After %s, got %s", "30", retryAfter)
+	}
+}
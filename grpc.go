@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCodes maps the built-in error types to their gRPC status codes.
+var grpcCodes = map[string]codes.Code{
+	NotFoundErrorType:                   codes.NotFound,
+	InternalServerErrorType:             codes.Internal,
+	BadRequestErrorType:                 codes.InvalidArgument,
+	UnauthorizedErrorType:               codes.Unauthenticated,
+	ForbiddenErrorType:                  codes.PermissionDenied,
+	ConflictErrorType:                   codes.AlreadyExists,
+	MethodNotAllowedErrorType:           codes.Unimplemented,
+	RequestTimeoutErrorType:             codes.DeadlineExceeded,
+	UnprocessableEntityErrorType:        codes.InvalidArgument,
+	TooManyRequestsErrorType:            codes.ResourceExhausted,
+	ClientClosedRequestErrorType:        codes.Canceled,
+	PaymentRequiredErrorType:            codes.FailedPrecondition,
+	GoneErrorType:                       codes.NotFound,
+	UnsupportedMediaTypeErrorType:       codes.InvalidArgument,
+	UnavailableForLegalReasonsErrorType: codes.PermissionDenied,
+}
+
+// grpcErrorTypes maps gRPC status codes back to error types. It is kept separate
+// from grpcCodes because several types share a code.
+var grpcErrorTypes = map[codes.Code]string{
+	codes.NotFound:          NotFoundErrorType,
+	codes.Internal:          InternalServerErrorType,
+	codes.InvalidArgument:   BadRequestErrorType,
+	codes.Unauthenticated:   UnauthorizedErrorType,
+	codes.PermissionDenied:  ForbiddenErrorType,
+	codes.AlreadyExists:     ConflictErrorType,
+	codes.Aborted:           ConflictErrorType,
+	codes.Unimplemented:     MethodNotAllowedErrorType,
+	codes.DeadlineExceeded:  RequestTimeoutErrorType,
+	codes.ResourceExhausted: TooManyRequestsErrorType,
+	codes.Canceled:          ClientClosedRequestErrorType,
+}
+
+// GRPCCode returns the gRPC status code for the error type, or codes.Unknown for types without a mapping.
+func (e *ApiError) GRPCCode() codes.Code {
+	if code, exists := grpcCodes[e.ErrorType]; exists {
+		return code
+	}
+	return codes.Unknown
+}
+
+// GRPCStatus returns the gRPC status for the error, which lets status.FromError and
+// grpc-go servers translate an ApiError returned from a handler.
+func (e *ApiError) GRPCStatus() *status.Status {
+	return status.New(e.GRPCCode(), e.message())
+}
+
+// FromGRPCStatus builds an ApiError from a gRPC status, using the status message as the
+// user message. Codes without a mapping become a GenericError. It returns nil for a nil
+// or OK status.
+func FromGRPCStatus(st *status.Status) *ApiError {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	errorType, exists := grpcErrorTypes[st.Code()]
+	if !exists {
+		errorType = GenericErrorType
+	}
+	return newApiError(errorType, st.Message(), nil)
+}
@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := []struct {
+		errorType string
+		expected  codes.Code
+	}{
+		{NotFoundErrorType, codes.NotFound},
+		{InternalServerErrorType, codes.Internal},
+		{BadRequestErrorType, codes.InvalidArgument},
+		{UnauthorizedErrorType, codes.Unauthenticated},
+		{ForbiddenErrorType, codes.PermissionDenied},
+		{ConflictErrorType, codes.AlreadyExists},
+		{MethodNotAllowedErrorType, codes.Unimplemented},
+		{RequestTimeoutErrorType, codes.DeadlineExceeded},
+		{UnprocessableEntityErrorType, codes.InvalidArgument},
+		{TooManyRequestsErrorType, codes.ResourceExhausted},
+		{ClientClosedRequestErrorType, codes.Canceled},
+		{PaymentRequiredErrorType, codes.FailedPrecondition},
+		{GoneErrorType, codes.NotFound},
+		{UnsupportedMediaTypeErrorType, codes.InvalidArgument},
+		{UnavailableForLegalReasonsErrorType, codes.PermissionDenied},
+		{"UnregisteredError", codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.errorType, func(t *testing.T) {
+			apiError := &ApiError{ErrorType: tt.errorType, Message: "something happened"}
+			if apiError.GRPCCode() != tt.expected {
+				t.Errorf("expected gRPC code %s, got %s", tt.expected, apiError.GRPCCode())
+			}
+		})
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	st, ok := status.FromError(NotFound("User not found"))
+	if !ok {
+		t.Fatal("expected status.FromError to recognize the ApiError")
+	}
+	if st.Code() != codes.NotFound {
+		t.Errorf("expected gRPC code %s, got %s", codes.NotFound, st.Code())
+	}
+	if st.Message() != "User not found" {
+		t.Errorf("expected message %s, got %s", "User not found", st.Message())
+	}
+}
+
+func TestGRPCStatusOfWrappedApiError(t *testing.T) {
+	err := fmt.Errorf("get user: %w", Forbidden("Access denied"))
+
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected gRPC code %s, got %s", codes.PermissionDenied, status.Code(err))
+	}
+}
+
+func TestFromGRPCStatus(t *testing.T) {
+	// A configured default type must not change the GenericError of unmapped codes.
+	t.Cleanup(func() { SetDefaultErrorType("") })
+	SetDefaultErrorType(BadRequestErrorType)
+	tests := []struct {
+		code         codes.Code
+		expectedType string
+		expectedCode int
+	}{
+		{codes.NotFound, NotFoundErrorType, http.StatusNotFound},
+		{codes.PermissionDenied, ForbiddenErrorType, http.StatusForbidden},
+		{codes.DataLoss, "GenericError", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			apiError := FromGRPCStatus(status.New(tt.code, "downstream failed"))
+
+			if apiError.ErrorType != tt.expectedType {
+				t.Errorf("expected error type %s, got %s", tt.expectedType, apiError.ErrorType)
+			}
+			if apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, apiError.ErrorCode)
+			}
+			if apiError.Message != "downstream failed" {
+				t.Errorf("expected message %s, got %s", "downstream failed", apiError.Message)
+			}
+		})
+	}
+}
+
+func TestFromGRPCStatusRoundTrip(t *testing.T) {
+	for _, original := range []*ApiError{NotFound("User not found").ApiError, Forbidden("Access denied").ApiError} {
+		apiError := FromGRPCStatus(original.GRPCStatus())
+
+		if apiError.ErrorType != original.ErrorType {
+			t.Errorf("expected error type %s, got %s", original.ErrorType, apiError.ErrorType)
+		}
+		if apiError.Message != original.Message {
+			t.Errorf("expected message %s, got %s", original.Message, apiError.Message)
+		}
+	}
+}
+
+func TestFromGRPCStatusOK(t *testing.T) {
+	if apiError := FromGRPCStatus(status.New(codes.OK, "")); apiError != nil {
+		t.Errorf("expected nil for an OK status, got %v", apiError)
+	}
+	if apiError := FromGRPCStatus(nil); apiError != nil {
+		t.Errorf("expected nil for a nil status, got %v", apiError)
+	}
+}
@@ -0,0 +1,251 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WriteError writes err as a JSON ApiError response with the headers from WriteHeaders,
+// or as problem details when SetDefaultContentType selects ProblemContentType. A MultiError
+// that no ApiError wraps is written with its resolved code and the headers of its most
+// severe error. Errors without an ApiError in their chain are reported as an
+// InternalServerError wrapping the original error. A nil err writes nothing.
+func WriteError(w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+	contentType := defaultContentType()
+	if multiError := multiErrorInChain(err); multiError != nil {
+		multiError.mostSevere().WriteHeaders(w.Header())
+		if contentType == ProblemContentType {
+			writeBody(w, multiError.Code(), contentType, multiError.ProblemJSON)
+			return
+		}
+		writeBody(w, multiError.Code(), contentType, multiError.MarshalJSON)
+		return
+	}
+	apiError := asApiError(err)
+	apiError.WriteHeaders(w.Header())
+	if contentType == ProblemContentType {
+		writeBody(w, apiError.ErrorCode, contentType, apiError.ProblemJSON)
+		return
+	}
+	writeBody(w, apiError.ErrorCode, contentType, apiError.MarshalJSON)
+}
+
+// WriteHeaders sets X-Error-Type, X-Error-Code and, when present, X-Trace-Id on h
+// for clients that inspect headers before the body, and Location for redirects set with
+// WithLocation. Retry-After is set when the error carries a retry hint. The header
+// values are made strictly ASCII with headerValue, and Location with locationValue.
+func (e *ApiError) WriteHeaders(h http.Header) {
+	h.Set("X-Error-Type", headerValue(e.ErrorType))
+	h.Set("X-Error-Code", strconv.Itoa(e.ErrorCode))
+	if e.TraceID != "" {
+		h.Set("X-Trace-Id", headerValue(e.TraceID))
+	}
+	if e.Location != "" {
+		h.Set("Location", locationValue(e.Location))
+	}
+	if seconds := e.retryAfterSeconds(); seconds > 0 {
+		h.Set("Retry-After", strconv.Itoa(seconds))
+	}
+}
+
+// headerValue makes s safe to use as an HTTP header value, so it cannot split the
+// response: line breaks and other control characters are handled as by stripControlChars,
+// and "%" and any byte outside printable ASCII are percent-encoded, as in "caf%C3%A9".
+// Because "%" is always encoded, an encoded value never reads like a literal one.
+func headerValue(s string) string {
+	return percentEncode(stripControlChars(s), func(c byte) bool {
+		return c < 0x20 || c > 0x7e || c == '%'
+	})
+}
+
+// locationValue makes s safe to use as a Location header like headerValue, but escapes it
+// as a URL: spaces and bytes outside printable ASCII are percent-encoded, while an
+// existing escape such as "%C3%A9" is kept as is.
+func locationValue(s string) string {
+	return percentEncode(stripControlChars(s), func(c byte) bool {
+		return c <= 0x20 || c > 0x7e
+	})
+}
+
+// percentEncode percent-encodes every byte of s for which encode reports true.
+func percentEncode(s string, encode func(c byte) bool) string {
+	i := 0
+	for i < len(s) && !encode(s[i]) {
+		i++
+	}
+	if i == len(s) {
+		return s
+	}
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	b.Grow(len(s) * 3)
+	b.WriteString(s[:i])
+	for ; i < len(s); i++ {
+		c := s[i]
+		if encode(c) {
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0f])
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// writeBody writes the output of marshal as a response with the given status code and content type.
+func writeBody(w http.ResponseWriter, code int, contentType string, marshal func() ([]byte, error)) {
+	body, err := marshal()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// Handler adapts an error-returning handler to an http.HandlerFunc, writing any returned error with WriteError.
+func Handler(next func(http.ResponseWriter, *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := next(w, r); err != nil {
+			WriteError(w, err)
+		}
+	}
+}
+
+// RecoverMiddleware recovers from panics in next and converts them to an InternalServerError
+// that keeps the panic value as its inner error and records a stack trace. The error is
+// logged with slog.Default at its LogLevel and written sanitized, so the panic value never
+// reaches the client. A panic with http.ErrAbortHandler is re-raised so the server can
+// abort the response as usual.
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			err, ok := recovered.(error)
+			if !ok {
+				err = fmt.Errorf("panic: %v", recovered)
+			}
+			apiError := InternalServer("", WithInternalError(err), WithStackTrace()).ApiError
+			slog.Log(r.Context(), apiError.LogLevel(), "recovered panic", "error", apiError)
+			WriteError(w, apiError.Sanitize())
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// correlationHeaders are the request headers WithHTTPRequest checks, in order, for a correlation ID.
+var correlationHeaders = []string{"X-Request-Id", "X-Correlation-Id"}
+
+// WithHTTPRequest records the method and path of r in the metadata under "http_method" and
+// "http_path", plus the first X-Request-Id or X-Correlation-Id header under "request_id".
+// The body, query string and other headers are left out as they may hold sensitive data.
+func WithHTTPRequest(r *http.Request) ErrorOption {
+	return func(ae *ApiError) {
+		if r == nil {
+			return
+		}
+		WithMetadata("http_method", r.Method)(ae)
+		if r.URL != nil {
+			WithMetadata("http_path", r.URL.Path)(ae)
+		}
+		for _, header := range correlationHeaders {
+			if id := r.Header.Get(header); id != "" {
+				WithMetadata("request_id", id)(ae)
+				break
+			}
+		}
+	}
+}
+
+// multiErrorInChain returns the non-empty MultiError in err's chain when no ApiError
+// comes before it, or nil otherwise.
+func multiErrorInChain(err error) *MultiError {
+	var multiError *MultiError
+	if !errors.As(err, &multiError) || multiError.Len() == 0 {
+		return nil
+	}
+	// The first ApiError in err's chain is the first aggregated error unless an
+	// ApiError wraps the MultiError.
+	var first *ApiError
+	if errors.As(err, &first) && first != multiError.errs[0] {
+		return nil
+	}
+	return multiError
+}
+
+// asApiError extracts the ApiError from err's chain, defaulting to an InternalServerError.
+func asApiError(err error) *ApiError {
+	if apiError := apiErrorInChain(err); apiError != nil {
+		return apiError
+	}
+	return NewApiError(InternalServerErrorType, "", WithInternalError(err))
+}
+
+// apiErrorInChain returns the first ApiError in err's chain, or nil if there is none.
+// The ApiError of a ValidationError is returned with its field messages, so they are
+// written with it.
+func apiErrorInChain(err error) *ApiError {
+	var apiError *ApiError
+	if !errors.As(err, &apiError) || apiError == nil {
+		return nil
+	}
+	var validationError *ValidationError
+	if errors.As(err, &validationError) && &validationError.ApiError == apiError {
+		return validationError.withFields()
+	}
+	return apiError
+}
+
+// FromHTTPResponse builds an ApiError from an error response. A JSON ApiError body is
+// decoded as is; otherwise the error type is derived from the status code. The body is
+// read but not closed, which is left to the caller.
+func FromHTTPResponse(resp *http.Response) (*ApiError, error) {
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		if body, err = io.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	apiError := &ApiError{}
+	if len(body) > 0 && apiError.UnmarshalJSON(body) == nil && apiError.ErrorType != "" {
+		return apiError, nil
+	}
+
+	if errorType, exists := ErrorTypeForCode(resp.StatusCode); exists {
+		return NewApiError(errorType, ""), nil
+	}
+	return NewApiError("", http.StatusText(resp.StatusCode), WithCode(resp.StatusCode)), nil
+}
+
+// WriteErrorLocalized is like WriteError but uses the message registered for the best
+// matching language in the request's Accept-Language header, for each aggregated error
+// of a MultiError too.
+func WriteErrorLocalized(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+	languages := parseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if multiError := multiErrorInChain(err); multiError != nil {
+		WriteError(w, multiError.localize(languages))
+		return
+	}
+	WriteError(w, asApiError(err).localize(languages))
+}
@@ -0,0 +1,431 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteError(t *testing.T) {
+	// Arrange
+	recorder := httptest.NewRecorder()
+	err := fmt.Errorf("load user: %w", NotFound("User not found"))
+
+	// Act
+	WriteError(recorder, err)
+
+	// Assert
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected content type %s, got %s", "application/json", contentType)
+	}
+	expectedJSON := `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`
+	if recorder.Body.String() != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, recorder.Body.String())
+	}
+}
+
+func TestWriteErrorDefaultsToInternalServerError(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, errors.New("connection refused"))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	expectedJSON := `{"internal_error":"connection refused","error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`
+	if recorder.Body.String() != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, recorder.Body.String())
+	}
+}
+
+func TestWriteErrorWithValidationError(t *testing.T) {
+	// Arrange
+	recorder := httptest.NewRecorder()
+	validationError := NewValidationError(WithMessage("Validation failed")).AddFieldError("email", "is required")
+
+	// Act
+	WriteError(recorder, fmt.Errorf("create user: %w", validationError))
+
+	// Assert
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+	expected := `{"error_type":"UnprocessableEntityError","message":"Validation failed","error_code":422,"status_text":"Unprocessable Entity","fields":{"email":["is required"]}}`
+	if recorder.Body.String() != expected {
+		t.Errorf("expected body %s, got %s", expected, recorder.Body.String())
+	}
+	if validationError.Extra != nil {
+		t.Errorf("expected the ValidationError to be left unchanged, got extra %v", validationError.Extra)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return Forbidden("Access denied")
+	})
+	recorder := httptest.NewRecorder()
+
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+	expectedJSON := `{"error_type":"ForbiddenError","message":"Access denied","error_code":403,"status_text":"Forbidden"}`
+	if recorder.Body.String() != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, recorder.Body.String())
+	}
+}
+
+func TestHandlerWithoutError(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	recorder := httptest.NewRecorder()
+
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, recorder.Code)
+	}
+	if recorder.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %s", recorder.Body.String())
+	}
+}
+
+func TestFromHTTPResponseWithJSONBody(t *testing.T) {
+	// Arrange: record a 404 JSON response
+	recorder := httptest.NewRecorder()
+	WriteError(recorder, NotFound("User not found"))
+	resp := recorder.Result()
+
+	// Act
+	apiError, err := FromHTTPResponse(resp)
+	if err != nil {
+		t.Fatalf("failed to build ApiError from response: %v", err)
+	}
+
+	// Assert
+	if apiError.ErrorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, apiError.ErrorType)
+	}
+	if apiError.Message != "User not found" {
+		t.Errorf("expected message %s, got %s", "User not found", apiError.Message)
+	}
+	if apiError.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, apiError.ErrorCode)
+	}
+}
+
+func TestFromHTTPResponseWithEmptyBody(t *testing.T) {
+	// Arrange: record a 503 response without a body
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusServiceUnavailable)
+	resp := recorder.Result()
+
+	// Act
+	apiError, err := FromHTTPResponse(resp)
+	if err != nil {
+		t.Fatalf("failed to build ApiError from response: %v", err)
+	}
+
+	// Assert
+	if apiError.ErrorCode != http.StatusServiceUnavailable {
+		t.Errorf("expected error code %d, got %d", http.StatusServiceUnavailable, apiError.ErrorCode)
+	}
+	if apiError.Message != "Service Unavailable" {
+		t.Errorf("expected message %s, got %s", "Service Unavailable", apiError.Message)
+	}
+}
+
+func TestFromHTTPResponseWithNonApiErrorBody(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Body:       io.NopCloser(strings.NewReader("slow down")),
+	}
+
+	apiError, err := FromHTTPResponse(resp)
+	if err != nil {
+		t.Fatalf("failed to build ApiError from response: %v", err)
+	}
+
+	if apiError.ErrorType != TooManyRequestsErrorType {
+		t.Errorf("expected error type %s, got %s", TooManyRequestsErrorType, apiError.ErrorType)
+	}
+	if apiError.Message != "Too many requests" {
+		t.Errorf("expected message %s, got %s", "Too many requests", apiError.Message)
+	}
+}
+
+func TestWriteErrorSetsRetryAfterHeader(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, TooManyRequests("Slow down", WithRetryAfter(1500*time.Millisecond)))
+
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != "2" {
+		t.Errorf("expected Retry-After %s, got %s", "2", retryAfter)
+	}
+}
+
+func TestWriteErrorWithoutRetryAfter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, TooManyRequests("Slow down"))
+
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != "" {
+		t.Errorf("expected no Retry-After header, got %s", retryAfter)
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	// Arrange
+	recorder := httptest.NewRecorder()
+	handler := RecoverMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("database connection lost")
+	}))
+
+	// Act
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	// Assert
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected content type %s, got %s", "application/json", contentType)
+	}
+	expected := `{"error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`
+	if body := recorder.Body.String(); body != expected {
+		t.Errorf("expected body %s, got %s", expected, body)
+	}
+}
+
+func TestRecoverMiddlewareLogsPanicError(t *testing.T) {
+	// Arrange: capture the default logger
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	var logs strings.Builder
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	panicErr := errors.New("nil map write")
+	handler := RecoverMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(panicErr)
+	}))
+	recorder := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert: the panic value is logged but kept out of the response
+	if !strings.Contains(logs.String(), panicErr.Error()) {
+		t.Errorf("expected the log to contain %v, got %s", panicErr, logs.String())
+	}
+	if strings.Contains(recorder.Body.String(), panicErr.Error()) {
+		t.Errorf("expected the body not to contain %v, got %s", panicErr, recorder.Body.String())
+	}
+}
+
+func TestRecoverMiddlewarePassesThrough(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("expected status %d, got %d", http.StatusNoContent, recorder.Code)
+	}
+}
+
+func TestWriteErrorSetsErrorHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected map[string]string
+	}{
+		{"with trace id", NotFound("User not found", WithTraceID("abc123")), map[string]string{"X-Error-Type": "NotFoundError", "X-Error-Code": "404", "X-Trace-Id": "abc123"}},
+		{"without trace id", Conflict("Email already taken"), map[string]string{"X-Error-Type": "ConflictError", "X-Error-Code": "409", "X-Trace-Id": ""}},
+		{"plain error", errors.New("boom"), map[string]string{"X-Error-Type": "InternalServerError", "X-Error-Code": "500", "X-Trace-Id": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			WriteError(recorder, tt.err)
+
+			for header, expected := range tt.expected {
+				if got := recorder.Header().Get(header); got != expected {
+					t.Errorf("expected %s %q, got %q", header, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteHeadersSetsRetryAfter(t *testing.T) {
+	header := http.Header{}
+
+	TooManyRequests("Slow down", WithRetryAfter(1500*time.Millisecond)).WriteHeaders(header)
+
+	if retryAfter := header.Get("Retry-After"); retryAfter != "2" {
+		t.Errorf("expected Retry-After %s, got %s", "2", retryAfter)
+	}
+}
+
+func TestWriteHeadersStripsControlCharacters(t *testing.T) {
+	header := http.Header{}
+	apiError := &ApiError{ErrorType: "NotFoundError\r\nX-Injected: 1", ErrorCode: http.StatusNotFound, TraceID: "abc\n123"}
+
+	apiError.WriteHeaders(header)
+
+	if got := header.Get("X-Error-Type"); got != "NotFoundError X-Injected: 1" {
+		t.Errorf("expected X-Error-Type %q, got %q", "NotFoundError X-Injected: 1", got)
+	}
+	if got := header.Get("X-Trace-Id"); got != "abc 123" {
+		t.Errorf("expected X-Trace-Id %q, got %q", "abc 123", got)
+	}
+}
+
+func TestWriteHeadersEncodesNonASCII(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		header   string
+		expected string
+	}{
+		{"newline in error type", &ApiError{ErrorType: "QuotaError\nSet-Cookie: session=1", ErrorCode: http.StatusTooManyRequests}, "X-Error-Type", "QuotaError Set-Cookie: session=1"},
+		{"non-ASCII error type", &ApiError{ErrorType: "CaféError", ErrorCode: http.StatusConflict}, "X-Error-Type", "Caf%C3%A9Error"},
+		{"non-ASCII and newline in trace id", &ApiError{ErrorType: NotFoundErrorType, ErrorCode: http.StatusNotFound, TraceID: "trace-ü\r\n1"}, "X-Trace-Id", "trace-%C3%BC 1"},
+		{"non-ASCII location", &ApiError{ErrorType: FoundErrorType, ErrorCode: http.StatusFound, Location: "https://example.com/straße"}, "Location", "https://example.com/stra%C3%9Fe"},
+		{"percent sign with non-ASCII", &ApiError{ErrorType: "Caf%C3éError", ErrorCode: http.StatusConflict}, "X-Error-Type", "Caf%25C3%C3%A9Error"},
+		{"percent sign in plain ASCII", &ApiError{ErrorType: "Caf%C3%A9Error", ErrorCode: http.StatusConflict}, "X-Error-Type", "Caf%25C3%25A9Error"},
+		{"location keeps existing escapes", &ApiError{ErrorType: FoundErrorType, ErrorCode: http.StatusFound, Location: "https://x/caf%C3%A9?q=ü"}, "Location", "https://x/caf%C3%A9?q=%C3%BC"},
+		{"space in location", &ApiError{ErrorType: FoundErrorType, ErrorCode: http.StatusFound, Location: "https://x/a b"}, "Location", "https://x/a%20b"},
+		{"plain ASCII unchanged", &ApiError{ErrorType: NotFoundErrorType, ErrorCode: http.StatusNotFound, TraceID: "4bf92f3577b34da6"}, "X-Trace-Id", "4bf92f3577b34da6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+
+			tt.apiError.WriteHeaders(header)
+
+			got := header.Get(tt.header)
+			if got != tt.expected {
+				t.Errorf("expected %s %q, got %q", tt.header, tt.expected, got)
+			}
+			for i := 0; i < len(got); i++ {
+				if got[i] < 0x20 || got[i] > 0x7e {
+					t.Errorf("expected printable ASCII %s, got byte %#x in %q", tt.header, got[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteErrorRedirect(t *testing.T) {
+	// Arrange
+	recorder := httptest.NewRecorder()
+	apiError := NewApiError(FoundErrorType, "", WithLocation("https://example.com/login"))
+
+	// Act
+	WriteError(recorder, apiError)
+
+	// Assert
+	if recorder.Code != http.StatusFound {
+		t.Errorf("expected status %d, got %d", http.StatusFound, recorder.Code)
+	}
+	if location := recorder.Header().Get("Location"); location != "https://example.com/login" {
+		t.Errorf("expected Location %s, got %s", "https://example.com/login", location)
+	}
+}
+
+func TestWriteErrorWithoutLocation(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, NewApiError(MovedPermanentlyErrorType, ""))
+
+	if recorder.Code != http.StatusMovedPermanently {
+		t.Errorf("expected status %d, got %d", http.StatusMovedPermanently, recorder.Code)
+	}
+	if location := recorder.Header().Get("Location"); location != "" {
+		t.Errorf("expected no Location header, got %s", location)
+	}
+}
+
+func TestWithHTTPRequest(t *testing.T) {
+	// Arrange
+	request := httptest.NewRequest(http.MethodPost, "/users/1?token=secret", strings.NewReader(`{"password":"hunter2"}`))
+	request.Header.Set("X-Request-Id", "req-123")
+	request.Header.Set("Authorization", "Bearer secret")
+
+	// Act
+	apiError := BadRequest("Invalid user", WithHTTPRequest(request))
+
+	// Assert
+	expected := map[string]any{"http_method": http.MethodPost, "http_path": "/users/1", "request_id": "req-123"}
+	if len(apiError.Metadata) != len(expected) {
+		t.Errorf("expected metadata %v, got %v", expected, apiError.Metadata)
+	}
+	for key, value := range expected {
+		if apiError.Metadata[key] != value {
+			t.Errorf("expected metadata %s %v, got %v", key, value, apiError.Metadata[key])
+		}
+	}
+}
+
+func TestWithHTTPRequestWithoutCorrelationHeader(t *testing.T) {
+	apiError := NotFound("User not found", WithHTTPRequest(httptest.NewRequest(http.MethodGet, "/users/1", nil)))
+
+	if _, exists := apiError.Metadata["request_id"]; exists {
+		t.Errorf("expected no request_id, got %v", apiError.Metadata["request_id"])
+	}
+	if apiError.Metadata["http_method"] != http.MethodGet {
+		t.Errorf("expected http_method %s, got %v", http.MethodGet, apiError.Metadata["http_method"])
+	}
+}
+
+func TestWriteErrorWithDefaultContentType(t *testing.T) {
+	t.Cleanup(func() { SetDefaultContentType("") })
+
+	tests := []struct {
+		name                string
+		contentType         string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{"json by default", "", "application/json", `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"problem details", ProblemContentType, ProblemContentType, `{"type":"/errors/NotFoundError","title":"Resource not found","status":404,"detail":"User not found"}`},
+		{"custom json type", "application/vnd.api+json", "application/vnd.api+json", `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetDefaultContentType(tt.contentType)
+			recorder := httptest.NewRecorder()
+
+			// Act
+			WriteError(recorder, NotFound("User not found"))
+
+			// Assert
+			if contentType := recorder.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf("expected content type %s, got %s", tt.expectedContentType, contentType)
+			}
+			if body := recorder.Body.String(); body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
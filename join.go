@@ -0,0 +1,48 @@
+package errors
+
+// Join creates an ApiError that carries several child errors, for example the
+// failures of a validation pass. The children are reachable through errors.Is and
+// errors.As and are serialized under "errors". Nil errors are discarded.
+func Join(errorType string, message string, errs ...error) *ApiError {
+	apiError := newApiError(errorType, message, nil)
+	for _, err := range errs {
+		if err != nil {
+			apiError.Errors = append(apiError.Errors, err)
+		}
+	}
+	return apiError
+}
+
+// JoinUnique is like Join but keeps only the first of several errors with the same
+// Error() string, for example a validation message repeated in a loop.
+func JoinUnique(errorType string, message string, errs ...error) *ApiError {
+	apiError := newApiError(errorType, message, nil)
+	seen := make(map[string]bool, len(errs))
+	for _, err := range errs {
+		if err == nil || seen[err.Error()] {
+			continue
+		}
+		seen[err.Error()] = true
+		apiError.Errors = append(apiError.Errors, err)
+	}
+	return apiError
+}
+
+// NewFromJoined is like Join but takes the result of errors.Join, or any error with an
+// Unwrap() []error method, and stores the errors it wraps as children. Any other non-nil
+// error becomes the only child.
+func NewFromJoined(errorType string, message string, joined error) *ApiError {
+	apiError := newApiError(errorType, message, nil)
+	if multi, ok := joined.(interface{ Unwrap() []error }); ok {
+		for _, err := range multi.Unwrap() {
+			if err != nil {
+				apiError.Errors = append(apiError.Errors, err)
+			}
+		}
+		return apiError
+	}
+	if joined != nil {
+		apiError.Errors = append(apiError.Errors, joined)
+	}
+	return apiError
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJoinMatchesEachChild(t *testing.T) {
+	// Arrange
+	emailErr := errors.New("email is required")
+	nameErr := errors.New("name is too long")
+	conflictErr := Conflict("Email already taken")
+
+	// Act
+	apiError := Join(UnprocessableEntityErrorType, "Validation failed", emailErr, nil, nameErr, conflictErr)
+
+	// Assert
+	if len(apiError.Errors) != 3 {
+		t.Fatalf("expected 3 child errors, got %d", len(apiError.Errors))
+	}
+	for _, child := range []error{emailErr, nameErr, conflictErr} {
+		if !errors.Is(apiError, child) {
+			t.Errorf("expected errors.Is to match child %v", child)
+		}
+	}
+	if !IsConflict(apiError.Errors[2]) {
+		t.Error("expected the conflict child to be an ApiError")
+	}
+}
+
+func TestJoinWithInnerErrorReachesEveryBranch(t *testing.T) {
+	// Arrange
+	internalErr := errors.New("transaction rolled back")
+	nameErr := errors.New("name is too long")
+	apiError := Join(UnprocessableEntityErrorType, "Validation failed", nameErr, Conflict("Email already taken"))
+	apiError.InnerError = internalErr
+
+	// Assert
+	for _, err := range []error{internalErr, nameErr, ErrConflict} {
+		if !errors.Is(errors.Unwrap(apiError), err) {
+			t.Errorf("expected the unwrapped error to match %v", err)
+		}
+	}
+	var conflict *ConflictError
+	if !errors.As(apiError, &conflict) {
+		t.Error("expected errors.As to find the conflict child")
+	}
+}
+
+func TestJoinMarshalJSON(t *testing.T) {
+	apiError := Join(UnprocessableEntityErrorType, "Validation failed", errors.New("email is required"), BadRequest("Invalid name"))
+
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	expectedJSON := `{"error_type":"UnprocessableEntityError","message":"Validation failed","error_code":422,"status_text":"Unprocessable Entity","errors":["email is required",{"error_type":"BadRequestError","message":"Invalid name","error_code":400,"status_text":"Bad Request"}]}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+
+	var decoded ApiError
+	if err := json.Unmarshal(jsonData, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if len(decoded.Errors) != 2 {
+		t.Fatalf("expected 2 child errors, got %d", len(decoded.Errors))
+	}
+	if decoded.Errors[0].Error() != "email is required" {
+		t.Errorf("expected child error %s, got %s", "email is required", decoded.Errors[0].Error())
+	}
+	if !IsBadRequest(decoded.Errors[1]) {
+		t.Errorf("expected second child to be a BadRequest ApiError, got %v", decoded.Errors[1])
+	}
+}
+
+func TestJoinUniqueCollapsesDuplicates(t *testing.T) {
+	// Arrange
+	emailErr := errors.New("email is required")
+	duplicateErr := errors.New("email is required")
+	nameErr := errors.New("name is too long")
+
+	// Act
+	apiError := JoinUnique(UnprocessableEntityErrorType, "Validation failed", emailErr, duplicateErr, nil, nameErr)
+
+	// Assert
+	if len(apiError.Errors) != 2 {
+		t.Fatalf("expected 2 child errors, got %d", len(apiError.Errors))
+	}
+	if apiError.Errors[0] != emailErr || apiError.Errors[1] != nameErr {
+		t.Errorf("expected children %v and %v, got %v", emailErr, nameErr, apiError.Errors)
+	}
+}
+
+func TestNewFromJoined(t *testing.T) {
+	// Arrange
+	emailErr := errors.New("email is required")
+	nameErr := BadRequest("Invalid name")
+	joined := errors.Join(emailErr, nameErr)
+
+	// Act
+	apiError := NewFromJoined(UnprocessableEntityErrorType, "Validation failed", joined)
+
+	// Assert
+	if len(apiError.Errors) != 2 || apiError.Errors[0] != emailErr || apiError.Errors[1] != nameErr {
+		t.Fatalf("expected children %v and %v, got %v", emailErr, nameErr, apiError.Errors)
+	}
+	if !errors.Is(apiError, emailErr) || !IsBadRequest(apiError.Errors[1]) {
+		t.Error("expected the joined errors to be reachable as children")
+	}
+}
+
+func TestNewFromJoinedWithSingleError(t *testing.T) {
+	emailErr := errors.New("email is required")
+
+	apiError := NewFromJoined(UnprocessableEntityErrorType, "Validation failed", emailErr)
+
+	if len(apiError.Errors) != 1 || apiError.Errors[0] != emailErr {
+		t.Errorf("expected a single child %v, got %v", emailErr, apiError.Errors)
+	}
+	if children := NewFromJoined(UnprocessableEntityErrorType, "Validation failed", nil).Errors; children != nil {
+		t.Errorf("expected no children for nil, got %v", children)
+	}
+}
@@ -0,0 +1,261 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// appendJSONField adds key and value as the last member of an encoded JSON object.
+func appendJSONField(object []byte, key string, value any) ([]byte, error) {
+	object = bytes.TrimSpace(object)
+	if len(object) < 2 || object[len(object)-1] != '}' {
+		return nil, errors.New("errors: cannot append field to a non-object JSON value")
+	}
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Write(object[:len(object)-1])
+	if len(bytes.TrimSpace(object[1:len(object)-1])) > 0 {
+		b.WriteByte(',')
+	}
+	b.Write(encodedKey)
+	b.WriteByte(':')
+	b.Write(encodedValue)
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// prependJSONField adds key and value as the first member of an encoded JSON object.
+func prependJSONField(object []byte, key string, value any) ([]byte, error) {
+	object = bytes.TrimSpace(object)
+	if len(object) < 2 || object[0] != '{' {
+		return nil, errors.New("errors: cannot prepend field to a non-object JSON value")
+	}
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	b.Write(encodedKey)
+	b.WriteByte(':')
+	b.Write(encodedValue)
+	if len(bytes.TrimSpace(object[1:len(object)-1])) > 0 {
+		b.WriteByte(',')
+	}
+	b.Write(object[1:])
+	return b.Bytes(), nil
+}
+
+// NamingStyle selects the casing of the top-level JSON keys of an ApiError.
+type NamingStyle int32
+
+const (
+	// SnakeCase emits keys such as "error_type". It is the default.
+	SnakeCase NamingStyle = iota
+	// CamelCase emits keys such as "errorType".
+	CamelCase
+)
+
+// applyNamingStyle renames the top-level keys of an encoded snake_case object to the configured style.
+func applyNamingStyle(object []byte) ([]byte, error) {
+	if NamingStyle(jsonNamingStyle.Load()) != CamelCase {
+		return object, nil
+	}
+	return renameJSONKeys(object, snakeToCamel)
+}
+
+// normalizeNamingStyle renames the top-level keys of an encoded object in the configured style back to snake_case.
+func normalizeNamingStyle(object []byte) ([]byte, error) {
+	if NamingStyle(jsonNamingStyle.Load()) != CamelCase {
+		return object, nil
+	}
+	return renameJSONKeys(object, camelToSnake)
+}
+
+// renameJSONKeys rewrites the top-level keys of an encoded JSON object, keeping their order
+// and leaving nested values untouched. Values that are not objects are returned unchanged.
+func renameJSONKeys(object []byte, rename func(string) string) ([]byte, error) {
+	return rewriteJSONMembers(object, func(key string, value json.RawMessage) (string, json.RawMessage, error) {
+		return rename(key), value, nil
+	})
+}
+
+// rewriteJSONMembers rewrites the top-level members of an encoded JSON object in order.
+// Values that are not objects are returned unchanged.
+func rewriteJSONMembers(object []byte, rewrite func(key string, value json.RawMessage) (string, json.RawMessage, error)) ([]byte, error) {
+	trimmed := bytes.TrimSpace(object)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return object, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if key, value, err = rewrite(key, value); err != nil {
+			return nil, err
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		b.Write(encodedKey)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// applyCodeFormat quotes the error_code of an encoded snake_case object while SetCodeAsString is enabled.
+func applyCodeFormat(object []byte) ([]byte, error) {
+	if !codeAsString.Load() {
+		return object, nil
+	}
+	return rewriteJSONMembers(object, func(key string, value json.RawMessage) (string, json.RawMessage, error) {
+		if key != "error_code" {
+			return key, value, nil
+		}
+		quoted, err := json.Marshal(string(value))
+		return key, quoted, err
+	})
+}
+
+// normalizeCodeFormat turns a string error_code of an encoded snake_case object back into a number.
+func normalizeCodeFormat(object []byte) ([]byte, error) {
+	return rewriteJSONMembers(object, func(key string, value json.RawMessage) (string, json.RawMessage, error) {
+		if key != "error_code" || len(value) == 0 || value[0] != '"' {
+			return key, value, nil
+		}
+		var code string
+		if err := json.Unmarshal(value, &code); err != nil {
+			return key, nil, err
+		}
+		number, err := strconv.Atoi(code)
+		if err != nil {
+			return key, nil, fmt.Errorf("errors: invalid error_code %q", code)
+		}
+		return key, json.RawMessage(strconv.Itoa(number)), nil
+	})
+}
+
+// snakeToCamel converts a snake_case key such as "error_type" to "errorType".
+func snakeToCamel(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// camelToSnake converts a camelCase key such as "errorType" to "error_type".
+func camelToSnake(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// knownJSONKeys are the top-level snake_case keys ApiError encodes itself.
+var knownJSONKeys = map[string]bool{
+	"internal_error":      true,
+	"internal_errors":     true,
+	"error_type":          true,
+	"message":             true,
+	"details":             true,
+	"error_code":          true,
+	"code":                true,
+	"help_url":            true,
+	"metadata":            true,
+	"trace_id":            true,
+	"developer_message":   true,
+	"status_text":         true,
+	"retry_after_seconds": true,
+	"timestamp":           true,
+	"stack":               true,
+	"errors":              true,
+}
+
+// unknownJSONFields returns the top-level members of an encoded object whose keys are not in knownJSONKeys.
+func unknownJSONFields(object []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(object, &fields); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	for key, value := range fields {
+		if knownJSONKeys[key] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[key] = value
+	}
+	return extra, nil
+}
+
+// appendExtra appends the Extra fields to an encoded ApiError in key order, skipping
+// any key that ApiError already encodes.
+func (e *ApiError) appendExtra(object []byte) ([]byte, error) {
+	keys := make([]string, 0, len(e.Extra))
+	for key := range e.Extra {
+		if !knownJSONKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var err error
+	for _, key := range keys {
+		if object, err = appendJSONField(object, key, e.Extra[key]); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
@@ -0,0 +1,69 @@
+package errors
+
+import "testing"
+
+func TestKeyCaseConversion(t *testing.T) {
+	tests := []struct {
+		snake string
+		camel string
+	}{
+		{"error_type", "errorType"},
+		{"retry_after_seconds", "retryAfterSeconds"},
+		{"message", "message"},
+	}
+
+	for _, tt := range tests {
+		if got := snakeToCamel(tt.snake); got != tt.camel {
+			t.Errorf("expected %s, got %s", tt.camel, got)
+		}
+		if got := camelToSnake(tt.camel); got != tt.snake {
+			t.Errorf("expected %s, got %s", tt.snake, got)
+		}
+	}
+}
+
+func TestAppendJSONField(t *testing.T) {
+	tests := []struct {
+		object   string
+		expected string
+	}{
+		{`{"a":1}`, `{"a":1,"b":true}`},
+		{`{}`, `{"b":true}`},
+	}
+
+	for _, tt := range tests {
+		got, err := appendJSONField([]byte(tt.object), "b", true)
+		if err != nil {
+			t.Fatalf("failed to append field: %v", err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, string(got))
+		}
+	}
+
+	if _, err := appendJSONField([]byte(`[1]`), "b", true); err == nil {
+		t.Error("expected an error when appending to a non-object")
+	}
+}
+
+func TestPrependJSONField(t *testing.T) {
+	tests := []struct {
+		name     string
+		object   string
+		expected string
+	}{
+		{"non-empty object", `{"b":2}`, `{"a":1,"b":2}`},
+		{"empty object", `{}`, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := prependJSONField([]byte(tt.object), "a", 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package errors
+
+import "strconv"
+
+// ErrorTypeKind identifies a built-in error type without relying on its string name.
+type ErrorTypeKind int
+
+const (
+	// KindGeneric is the zero value and stands for GenericError and any unknown type.
+	KindGeneric ErrorTypeKind = iota
+	KindNotFound
+	KindInternalServer
+	KindBadRequest
+	KindUnauthorized
+	KindForbidden
+	KindConflict
+	KindMethodNotAllowed
+	KindRequestTimeout
+	KindUnprocessableEntity
+	KindTooManyRequests
+)
+
+// kindErrorTypes maps each kind to its error type name.
+var kindErrorTypes = []string{
+	KindGeneric:             GenericErrorType,
+	KindNotFound:            NotFoundErrorType,
+	KindInternalServer:      InternalServerErrorType,
+	KindBadRequest:          BadRequestErrorType,
+	KindUnauthorized:        UnauthorizedErrorType,
+	KindForbidden:           ForbiddenErrorType,
+	KindConflict:            ConflictErrorType,
+	KindMethodNotAllowed:    MethodNotAllowedErrorType,
+	KindRequestTimeout:      RequestTimeoutErrorType,
+	KindUnprocessableEntity: UnprocessableEntityErrorType,
+	KindTooManyRequests:     TooManyRequestsErrorType,
+}
+
+// String returns the error type name of the kind, such as "NotFoundError".
+func (k ErrorTypeKind) String() string {
+	if k < 0 || int(k) >= len(kindErrorTypes) {
+		return "ErrorTypeKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindErrorTypes[k]
+}
+
+// NewApiErrorKind is like NewApiError but takes the error type as an ErrorTypeKind.
+func NewApiErrorKind(kind ErrorTypeKind, userMessage string, options ...ErrorOption) *ApiError {
+	return newApiError(kind.String(), userMessage, options)
+}
+
+// Kind returns the ErrorTypeKind of the error's type, or KindGeneric for types without a kind.
+func (e *ApiError) Kind() ErrorTypeKind {
+	if e == nil {
+		return KindGeneric
+	}
+	for kind, errorType := range kindErrorTypes {
+		if errorType == e.ErrorType {
+			return ErrorTypeKind(kind)
+		}
+	}
+	return KindGeneric
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestErrorTypeKind(t *testing.T) {
+	tests := []struct {
+		kind         ErrorTypeKind
+		expectedType string
+		expectedCode int
+	}{
+		{KindGeneric, GenericErrorType, http.StatusInternalServerError},
+		{KindNotFound, NotFoundErrorType, http.StatusNotFound},
+		{KindInternalServer, InternalServerErrorType, http.StatusInternalServerError},
+		{KindBadRequest, BadRequestErrorType, http.StatusBadRequest},
+		{KindUnauthorized, UnauthorizedErrorType, http.StatusUnauthorized},
+		{KindForbidden, ForbiddenErrorType, http.StatusForbidden},
+		{KindConflict, ConflictErrorType, http.StatusConflict},
+		{KindMethodNotAllowed, MethodNotAllowedErrorType, http.StatusMethodNotAllowed},
+		{KindRequestTimeout, RequestTimeoutErrorType, http.StatusRequestTimeout},
+		{KindUnprocessableEntity, UnprocessableEntityErrorType, http.StatusUnprocessableEntity},
+		{KindTooManyRequests, TooManyRequestsErrorType, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expectedType, func(t *testing.T) {
+			// Act
+			apiError := NewApiErrorKind(tt.kind, "")
+
+			// Assert
+			if tt.kind.String() != tt.expectedType {
+				t.Errorf("expected %s, got %s", tt.expectedType, tt.kind.String())
+			}
+			if apiError.ErrorType != tt.expectedType {
+				t.Errorf("expected error type %s, got %s", tt.expectedType, apiError.ErrorType)
+			}
+			if apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, apiError.ErrorCode)
+			}
+			if apiError.Kind() != tt.kind {
+				t.Errorf("expected kind %s, got %s", tt.kind, apiError.Kind())
+			}
+		})
+	}
+}
+
+func TestErrorTypeKindOutOfRange(t *testing.T) {
+	if got := ErrorTypeKind(99).String(); got != "ErrorTypeKind(99)" {
+		t.Errorf("expected %s, got %s", "ErrorTypeKind(99)", got)
+	}
+}
+
+func TestKindOfCustomType(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+
+	if kind := NewApiError("PreconditionFailedError", "").Kind(); kind != KindGeneric {
+		t.Errorf("expected kind %s, got %s", KindGeneric, kind)
+	}
+}
@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// lazyMessage is a message formatted on first use. It is held by pointer so that
+// copies of an ApiError share the cached result.
+type lazyMessage struct {
+	once   sync.Once
+	format string
+	args   []any
+	text   string
+}
+
+// String formats the message on the first call and returns the cached text afterwards.
+func (l *lazyMessage) String() string {
+	l.once.Do(func() {
+		l.text = stripControlChars(fmt.Sprintf(l.format, l.args...))
+		l.args = nil
+	})
+	return l.text
+}
+
+// NewApiErrorLazyf is like NewApiErrorf but defers fmt.Sprintf until the message is first
+// needed, by UserMessage, Error, HTTPError or one of the encoders, and caches the result.
+// It saves formatting time, not memory: the deferred format and args take slightly more
+// space than the formatted message, so use it only where errors are often discarded.
+//
+// The Message field stays empty, so read the message with UserMessage; setting Message
+// later takes precedence. The args must not be modified after the call, as they are
+// only read when the message is formatted.
+func NewApiErrorLazyf(errorType string, format string, args ...any) *ApiError {
+	return newApiError(errorType, "", []ErrorOption{withLazyMessage(format, args)})
+}
+
+// withLazyMessage defers formatting the message until it is first needed.
+func withLazyMessage(format string, args []any) ErrorOption {
+	return func(ae *ApiError) {
+		ae.lazyMessage = &lazyMessage{format: format, args: args}
+	}
+}
+
+// message returns Message, or the lazily formatted message while Message is empty.
+func (e *ApiError) message() string {
+	if e.Message == "" && e.lazyMessage != nil {
+		return e.lazyMessage.String()
+	}
+	return e.Message
+}
@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// countingStringer counts how often it is formatted.
+type countingStringer struct {
+	calls *int
+}
+
+func (c countingStringer) String() string {
+	*c.calls++
+	return "42"
+}
+
+func TestNewApiErrorLazyfFormatsMessage(t *testing.T) {
+	apiError := NewApiErrorLazyf(NotFoundErrorType, "user %d not found", 42)
+
+	if apiError.Error() != "Error 404: user 42 not found" {
+		t.Errorf("expected %s, got %s", "Error 404: user 42 not found", apiError.Error())
+	}
+	if code, message := apiError.HTTPError(); code != http.StatusNotFound || message != "user 42 not found" {
+		t.Errorf("expected 404 and %s, got %d and %s", "user 42 not found", code, message)
+	}
+	jsonData, err := json.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+	expectedJSON := `{"error_type":"NotFoundError","message":"user 42 not found","error_code":404,"status_text":"Not Found"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+}
+
+func TestUserMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"eager message", NotFound("User not found").ApiError, "User not found"},
+		{"lazy message", NewApiErrorLazyf(NotFoundErrorType, "user %d not found", 42), "user 42 not found"},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.apiError.UserMessage(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewApiErrorLazyfFormatsOnlyOnce(t *testing.T) {
+	// Arrange
+	var calls int
+	apiError := NewApiErrorLazyf(NotFoundErrorType, "user %s not found", countingStringer{&calls})
+	clone := apiError.Clone()
+
+	// Act
+	if calls != 0 {
+		t.Fatalf("expected no formatting before first use, got %d calls", calls)
+	}
+	_ = apiError.Error()
+	_ = apiError.Error()
+	_, _ = json.Marshal(apiError)
+	_ = clone.Error()
+
+	// Assert
+	if calls != 1 {
+		t.Errorf("expected message to be formatted once, got %d calls", calls)
+	}
+	if clone.Error() != "Error 404: user 42 not found" {
+		t.Errorf("expected %s, got %s", "Error 404: user 42 not found", clone.Error())
+	}
+}
+
+func TestNewApiErrorLazyfMessageFieldTakesPrecedence(t *testing.T) {
+	apiError := NewApiErrorLazyf(NotFoundErrorType, "user %d not found", 42)
+	apiError.Message = "User not found"
+
+	if apiError.Error() != "Error 404: User not found" {
+		t.Errorf("expected %s, got %s", "Error 404: User not found", apiError.Error())
+	}
+}
+
+func TestNewApiErrorLazyfStripsControlCharacters(t *testing.T) {
+	apiError := NewApiErrorLazyf(BadRequestErrorType, "invalid name %q\n", "bob\tsmith")
+
+	expected := fmt.Sprintf("Error 400: invalid name %q ", "bob\tsmith")
+	if apiError.Error() != expected {
+		t.Errorf("expected %s, got %s", expected, apiError.Error())
+	}
+}
+
+func BenchmarkNewApiErrorf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewApiErrorf(NotFoundErrorType, "user %d not found", i)
+	}
+}
+
+func BenchmarkNewApiErrorLazyf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewApiErrorLazyf(NotFoundErrorType, "user %d not found", i)
+	}
+}
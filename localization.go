@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RegisterLocalized implements LocalizedRegistry.
+func (r *registry) RegisterLocalized(errorType, lang, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.localized == nil {
+		r.localized = make(map[string]map[string]string)
+	}
+	if r.localized[errorType] == nil {
+		r.localized[errorType] = make(map[string]string)
+	}
+	r.localized[errorType][normalizeLanguage(lang)] = message
+}
+
+// LookupLocalized implements LocalizedRegistry, falling back from a regional tag such as
+// "fa-IR" to its base language "fa".
+func (r *registry) LookupLocalized(errorType, lang string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	messages := r.localized[errorType]
+	lang = normalizeLanguage(lang)
+	if message, exists := messages[lang]; exists {
+		return message, true
+	}
+	if base, _, found := strings.Cut(lang, "-"); found {
+		message, exists := messages[base]
+		return message, exists
+	}
+	return "", false
+}
+
+// RegisterLocalizedMessage registers the message shown for errorType in the language lang,
+// for example "fa" or "en-GB". It is safe for concurrent use.
+func RegisterLocalizedMessage(errorType, lang, message string) {
+	activeLocalizedRegistry().RegisterLocalized(errorType, lang, message)
+}
+
+// LocalizedMessage returns the message registered for the error type in lang,
+// falling back to the error's own message when the locale has none.
+func (e *ApiError) LocalizedMessage(lang string) string {
+	if message, exists := activeLocalizedRegistry().LookupLocalized(e.ErrorType, lang); exists {
+		return message
+	}
+	return e.message()
+}
+
+// localize returns a copy of the error using the first language in languages that has a
+// registered message, or the error itself when none does.
+func (e *ApiError) localize(languages []string) *ApiError {
+	for _, lang := range languages {
+		if message, exists := activeLocalizedRegistry().LookupLocalized(e.ErrorType, lang); exists {
+			localized := e.Clone()
+			localized.Message = message
+			return localized
+		}
+	}
+	return e
+}
+
+// localize returns a MultiError whose aggregated errors are localized as by ApiError.localize.
+func (m *MultiError) localize(languages []string) *MultiError {
+	localized := &MultiError{errs: make([]*ApiError, len(m.errs))}
+	for i, err := range m.errs {
+		localized.errs[i] = err.localize(languages)
+	}
+	return localized
+}
+
+// normalizeLanguage lower-cases a language tag and uses "-" as the subtag separator.
+func normalizeLanguage(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header ordered by
+// descending quality. Tags with a quality of zero and the "*" wildcard are skipped.
+func parseAcceptLanguage(header string) []string {
+	type weightedLanguage struct {
+		tag     string
+		quality float64
+	}
+	var languages []weightedLanguage
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if tag == "" || tag == "*" || quality <= 0 {
+			continue
+		}
+		languages = append(languages, weightedLanguage{tag, quality})
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := make([]string, len(languages))
+	for i, language := range languages {
+		tags[i] = language.tag
+	}
+	return tags
+}
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalizedMessage(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterLocalizedMessage(NotFoundErrorType, "fa", "منبع یافت نشد")
+	apiError := NotFound("Resource not found")
+
+	tests := []struct {
+		lang     string
+		expected string
+	}{
+		{"fa", "منبع یافت نشد"},
+		{"fa-IR", "منبع یافت نشد"},
+		{"FA", "منبع یافت نشد"},
+		{"de", "Resource not found"},
+		{"", "Resource not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if message := apiError.LocalizedMessage(tt.lang); message != tt.expected {
+				t.Errorf("expected message %s, got %s", tt.expected, message)
+			}
+		})
+	}
+}
+
+func TestResetRegistryClearsLocalizedMessages(t *testing.T) {
+	RegisterLocalizedMessage(NotFoundErrorType, "fa", "منبع یافت نشد")
+
+	ResetRegistry()
+
+	if message := NotFound("Resource not found").LocalizedMessage("fa"); message != "Resource not found" {
+		t.Errorf("expected message %s, got %s", "Resource not found", message)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	languages := parseAcceptLanguage("de;q=0.5, fa-IR, en;q=0.8, *;q=0.1, fr;q=0")
+
+	expected := []string{"fa-IR", "en", "de"}
+	if len(languages) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, languages)
+	}
+	for i, lang := range expected {
+		if languages[i] != lang {
+			t.Errorf("expected language %s at position %d, got %s", lang, i, languages[i])
+		}
+	}
+}
+
+func TestWriteErrorLocalized(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterLocalizedMessage(NotFoundErrorType, "fa", "منبع یافت نشد")
+	request := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	request.Header.Set("Accept-Language", "de;q=0.9, fa-IR;q=0.8")
+	recorder := httptest.NewRecorder()
+	apiError := NotFound("Resource not found")
+
+	WriteErrorLocalized(recorder, request, apiError)
+
+	expectedJSON := `{"error_type":"NotFoundError","message":"منبع یافت نشد","error_code":404,"status_text":"Not Found"}`
+	if recorder.Body.String() != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, recorder.Body.String())
+	}
+	if apiError.Message != "Resource not found" {
+		t.Errorf("expected original message to be unchanged, got %s", apiError.Message)
+	}
+}
+
+func TestWriteErrorLocalizedWithMultiError(t *testing.T) {
+	// Arrange
+	t.Cleanup(ResetRegistry)
+	RegisterLocalizedMessage(NotFoundErrorType, "fa", "منبع یافت نشد")
+	request := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	request.Header.Set("Accept-Language", "fa")
+	recorder := httptest.NewRecorder()
+	var multiError MultiError
+	multiError.Add(NotFound("Resource not found").ApiError, InternalServer("Database unreachable").ApiError)
+
+	// Act
+	WriteErrorLocalized(recorder, request, multiError.ErrorOrNil())
+
+	// Assert
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	expectedJSON := `{"error_code":500,"errors":[{"error_type":"NotFoundError","message":"منبع یافت نشد","error_code":404,"status_text":"Not Found"},{"error_type":"InternalServerError","message":"Database unreachable","error_code":500,"status_text":"Internal Server Error"}]}`
+	if recorder.Body.String() != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, recorder.Body.String())
+	}
+}
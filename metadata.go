@@ -0,0 +1,27 @@
+package errors
+
+// MetadataString returns the metadata value for key if it is a string.
+func (e *ApiError) MetadataString(key string) (string, bool) {
+	value, ok := e.Metadata[key].(string)
+	return value, ok
+}
+
+// MetadataInt returns the metadata value for key if it is an int. A whole float64, as
+// produced when metadata is decoded from JSON, is accepted as well.
+func (e *ApiError) MetadataInt(key string) (int, bool) {
+	switch value := e.Metadata[key].(type) {
+	case int:
+		return value, true
+	case float64:
+		if value == float64(int(value)) {
+			return int(value), true
+		}
+	}
+	return 0, false
+}
+
+// MetadataBool returns the metadata value for key if it is a bool.
+func (e *ApiError) MetadataBool(key string) (bool, bool) {
+	value, ok := e.Metadata[key].(bool)
+	return value, ok
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetadataGetters(t *testing.T) {
+	apiError := NotFound("User not found", WithMetadata("resource", "user"), WithMetadata("id", 42), WithMetadata("archived", true))
+
+	tests := []struct {
+		name     string
+		get      func() (any, bool)
+		expected any
+		found    bool
+	}{
+		{"string present", func() (any, bool) { return apiError.MetadataString("resource") }, "user", true},
+		{"string absent", func() (any, bool) { return apiError.MetadataString("missing") }, "", false},
+		{"string wrong type", func() (any, bool) { return apiError.MetadataString("id") }, "", false},
+		{"int present", func() (any, bool) { return apiError.MetadataInt("id") }, 42, true},
+		{"int absent", func() (any, bool) { return apiError.MetadataInt("missing") }, 0, false},
+		{"int wrong type", func() (any, bool) { return apiError.MetadataInt("resource") }, 0, false},
+		{"bool present", func() (any, bool) { return apiError.MetadataBool("archived") }, true, true},
+		{"bool absent", func() (any, bool) { return apiError.MetadataBool("missing") }, false, false},
+		{"bool wrong type", func() (any, bool) { return apiError.MetadataBool("resource") }, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, found := tt.get()
+			if value != tt.expected || found != tt.found {
+				t.Errorf("expected (%v, %t), got (%v, %t)", tt.expected, tt.found, value, found)
+			}
+		})
+	}
+}
+
+func TestMetadataIntAfterJSONRoundTrip(t *testing.T) {
+	var decoded ApiError
+	if err := json.Unmarshal([]byte(`{"error_type":"NotFoundError","message":"User not found","metadata":{"id":42,"ratio":0.5}}`), &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if id, ok := decoded.MetadataInt("id"); !ok || id != 42 {
+		t.Errorf("expected (%d, true), got (%d, %t)", 42, id, ok)
+	}
+	if _, ok := decoded.MetadataInt("ratio"); ok {
+		t.Error("expected a fractional number not to be an int")
+	}
+}
+
+func TestMetadataGettersWithoutMetadata(t *testing.T) {
+	apiError := NotFound("User not found")
+
+	if _, ok := apiError.MetadataString("resource"); ok {
+		t.Error("expected no metadata value")
+	}
+}
@@ -0,0 +1,23 @@
+package errors
+
+import "strings"
+
+// genericMetricLabel is the label of GenericError and of every unregistered type.
+const genericMetricLabel = "generic"
+
+// MetricLabel returns a low-cardinality label for metrics, such as a Prometheus
+// error_type label. It is the snake_case type name without the "Error" suffix, for
+// example "not_found" for NotFoundError. Types missing from the registry map to "generic".
+func (e *ApiError) MetricLabel() string {
+	if e == nil {
+		return genericMetricLabel
+	}
+	if _, exists := LookupErrorType(e.ErrorType); !exists {
+		return genericMetricLabel
+	}
+	label := camelToSnake(strings.TrimSuffix(e.ErrorType, "Error"))
+	if label == "" {
+		return genericMetricLabel
+	}
+	return label
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMetricLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"not found", NotFound("User not found").ApiError, "not_found"},
+		{"bad request", BadRequest("Invalid id").ApiError, "bad_request"},
+		{"internal server", InternalServer("boom").ApiError, "internal_server"},
+		{"too many requests", TooManyRequests("Slow down").ApiError, "too_many_requests"},
+		{"generic", NewApiError("UnknownError", "Oops"), "generic"},
+		{"unregistered type", &ApiError{ErrorType: "MadeUpError", ErrorCode: http.StatusTeapot}, "generic"},
+		{"nil", nil, "generic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if label := tt.apiError.MetricLabel(); label != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, label)
+			}
+		})
+	}
+}
+
+func TestMetricLabelForCustomType(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("PaymentRequiredError", http.StatusPaymentRequired, "Payment required")
+
+	if label := NewApiError("PaymentRequiredError", "").MetricLabel(); label != "payment_required" {
+		t.Errorf("expected %s, got %s", "payment_required", label)
+	}
+}
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MultiError aggregates several ApiErrors into a single error, for example to return
+// every failure of a batch in one response. The zero value is ready to use.
+type MultiError struct {
+	errs []*ApiError
+}
+
+// Add appends errors to the aggregate, ignoring nil values.
+func (m *MultiError) Add(errs ...*ApiError) {
+	for _, err := range errs {
+		if err != nil {
+			m.errs = append(m.errs, err)
+		}
+	}
+}
+
+// Len returns the number of aggregated errors.
+func (m *MultiError) Len() int {
+	return len(m.errs)
+}
+
+// Errors returns a copy of the aggregated errors.
+func (m *MultiError) Errors() []*ApiError {
+	return append([]*ApiError(nil), m.errs...)
+}
+
+// ErrorOrNil returns the MultiError as an error, or nil when it is empty.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Code returns the most severe status code among the aggregated errors: server errors
+// win over client errors, and within a class the highest code wins. It is 0 when empty.
+func (m *MultiError) Code() int {
+	code := 0
+	for _, err := range m.errs {
+		if err.ErrorCode > code {
+			code = err.ErrorCode
+		}
+	}
+	return code
+}
+
+// mostSevere returns the first aggregated error with the code reported by Code, or nil when empty.
+func (m *MultiError) mostSevere() *ApiError {
+	code := m.Code()
+	for _, err := range m.errs {
+		if err.ErrorCode == code {
+			return err
+		}
+	}
+	return nil
+}
+
+// Error joins the messages of the aggregated errors with "; ".
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the aggregated errors so errors.Is and errors.As can inspect each of them.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.errs))
+	for i, err := range m.errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// MarshalJSON serializes the resolved status code and the list of aggregated errors.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	errs := m.errs
+	if errs == nil {
+		errs = []*ApiError{}
+	}
+	data, err := json.Marshal(&struct {
+		ErrorCode int         `json:"error_code"`
+		Errors    []*ApiError `json:"errors"`
+	}{
+		ErrorCode: m.Code(),
+		Errors:    errs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data, err = applyCodeFormat(data); err != nil {
+		return nil, err
+	}
+	return applyNamingStyle(data)
+}
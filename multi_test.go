@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMultiErrorResolvesMostSevereCode(t *testing.T) {
+	// Arrange
+	var multiError MultiError
+	multiError.Add(NotFound("User not found").ApiError, nil, InternalServer("Database unreachable").ApiError, Conflict("Email already taken").ApiError)
+
+	// Assert
+	if multiError.Len() != 3 {
+		t.Errorf("expected 3 errors, got %d", multiError.Len())
+	}
+	if multiError.Code() != http.StatusInternalServerError {
+		t.Errorf("expected code %d, got %d", http.StatusInternalServerError, multiError.Code())
+	}
+	if !errors.Is(&multiError, ErrNotFound) || !errors.Is(&multiError, ErrConflict) {
+		t.Error("expected errors.Is to match the aggregated errors")
+	}
+}
+
+func TestMultiErrorOnlyClientErrors(t *testing.T) {
+	var multiError MultiError
+	multiError.Add(NotFound("User not found").ApiError, Conflict("Email already taken").ApiError)
+
+	if multiError.Code() != http.StatusConflict {
+		t.Errorf("expected code %d, got %d", http.StatusConflict, multiError.Code())
+	}
+	if multiError.Error() != "Error 404: User not found; Error 409: Email already taken" {
+		t.Errorf("expected joined message, got %s", multiError.Error())
+	}
+}
+
+func TestMultiErrorErrorOrNil(t *testing.T) {
+	var multiError MultiError
+	if err := multiError.ErrorOrNil(); err != nil {
+		t.Errorf("expected nil for an empty MultiError, got %v", err)
+	}
+
+	multiError.Add(NotFound("User not found").ApiError)
+	if err := multiError.ErrorOrNil(); err == nil {
+		t.Error("expected an error for a non-empty MultiError")
+	}
+}
+
+func TestMultiErrorMarshalJSON(t *testing.T) {
+	var multiError MultiError
+	multiError.Add(NotFound("User not found").ApiError, InternalServer("Database unreachable").ApiError)
+
+	jsonData, err := json.Marshal(&multiError)
+	if err != nil {
+		t.Fatalf("failed to marshal MultiError: %v", err)
+	}
+
+	expectedJSON := `{"error_code":500,"errors":[{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"},{"error_type":"InternalServerError","message":"Database unreachable","error_code":500,"status_text":"Internal Server Error"}]}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+}
+
+func TestWriteErrorWithMultiError(t *testing.T) {
+	var multiError MultiError
+	multiError.Add(NotFound("User not found").ApiError, InternalServer("Database unreachable").ApiError)
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, multiError.ErrorOrNil())
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+}
+
+func TestWriteErrorWithApiErrorWrappingMultiError(t *testing.T) {
+	// Arrange: the outer ApiError comes first in the chain
+	var multiError MultiError
+	multiError.Add(Conflict("Email already taken").ApiError)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	WriteError(recorder, NewApiError(BadRequestErrorType, "outer", WithInternalError(&multiError)))
+
+	// Assert
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+	expectedJSON := `{"internal_error":"Error 409: Email already taken","error_type":"BadRequestError","message":"outer","error_code":400,"status_text":"Bad Request"}`
+	if recorder.Body.String() != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, recorder.Body.String())
+	}
+}
+
+func TestWriteErrorWithMultiErrorSetsHeadersOfMostSevereError(t *testing.T) {
+	// Arrange
+	var multiError MultiError
+	multiError.Add(BadRequest("Invalid id").ApiError, TooManyRequests("Slow down", WithRetryAfter(30*time.Second)).ApiError)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	WriteError(recorder, multiError.ErrorOrNil())
+
+	// Assert
+	expected := map[string]string{
+		"Content-Type": "application/json",
+		"X-Error-Type": TooManyRequestsErrorType,
+		"X-Error-Code": "429",
+		"Retry-After":  "30",
+	}
+	for header, value := range expected {
+		if got := recorder.Header().Get(header); got != value {
+			t.Errorf("expected %s header %s, got %s", header, value, got)
+		}
+	}
+}
+
+func TestWriteErrorWithMultiErrorAsProblemDetails(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { SetDefaultContentType("") })
+	SetDefaultContentType(ProblemContentType)
+	var multiError MultiError
+	multiError.Add(BadRequest("Invalid id").ApiError, TooManyRequests("Slow down", WithRetryAfter(30*time.Second)).ApiError)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	WriteError(recorder, multiError.ErrorOrNil())
+
+	// Assert
+	if contentType := recorder.Header().Get("Content-Type"); contentType != ProblemContentType {
+		t.Errorf("expected content type %s, got %s", ProblemContentType, contentType)
+	}
+	if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != "30" {
+		t.Errorf("expected Retry-After %s, got %s", "30", retryAfter)
+	}
+	expectedBody := `{"type":"/errors/TooManyRequestsError","title":"Too many requests","status":429,"detail":"Slow down","errors":[{"type":"/errors/BadRequestError","title":"Bad request","status":400,"detail":"Invalid id"},{"type":"/errors/TooManyRequestsError","title":"Too many requests","status":429,"detail":"Slow down"}]}`
+	if body := recorder.Body.String(); body != expectedBody {
+		t.Errorf("expected body %s, got %s", expectedBody, body)
+	}
+}
@@ -0,0 +1,29 @@
+package errors
+
+import "strconv"
+
+// openAPISchemaRef references the ApiError schema in the components section of a spec.
+const openAPISchemaRef = "#/components/schemas/ApiError"
+
+// OpenAPIResponses returns an OpenAPI responses fragment with one entry per registered
+// status code, keyed by the code as a string. Each entry describes a JSON ApiError body
+// and uses the default message as its description; when several types share a code, the
+// one registered first is used, as with ErrorTypeForCode.
+func OpenAPIResponses() map[string]any {
+	responses := make(map[string]any)
+	for _, errorType := range orderedErrorTypes() {
+		code := strconv.Itoa(errorType.ErrorCode)
+		if _, exists := responses[code]; exists {
+			continue
+		}
+		responses[code] = map[string]any{
+			"description": errorType.Message,
+			"content": map[string]any{
+				"application/json": map[string]any{
+					"schema": map[string]any{"$ref": openAPISchemaRef},
+				},
+			},
+		}
+	}
+	return responses
+}
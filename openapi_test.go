@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOpenAPIResponses(t *testing.T) {
+	// Act
+	responses := OpenAPIResponses()
+
+	// Assert
+	notFound, ok := responses["404"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a 404 response, got %v", responses["404"])
+	}
+	if notFound["description"] != "Resource not found" {
+		t.Errorf("expected description %s, got %v", "Resource not found", notFound["description"])
+	}
+	schema := notFound["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	if schema["$ref"] != "#/components/schemas/ApiError" {
+		t.Errorf("expected schema ref %s, got %v", "#/components/schemas/ApiError", schema["$ref"])
+	}
+}
+
+func TestOpenAPIResponsesUsesFirstRegisteredTypePerCode(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("MissingUserError", http.StatusNotFound, "User not found")
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+
+	responses := OpenAPIResponses()
+
+	if description := responses["404"].(map[string]any)["description"]; description != "Resource not found" {
+		t.Errorf("expected description %s, got %v", "Resource not found", description)
+	}
+	if description := responses["412"].(map[string]any)["description"]; description != "Precondition failed" {
+		t.Errorf("expected description %s, got %v", "Precondition failed", description)
+	}
+}
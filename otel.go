@@ -0,0 +1,37 @@
+package errors
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordOnSpan records the error on span, sets the span status to Error and adds
+// the error.type and error.code attributes.
+func (e *ApiError) RecordOnSpan(span trace.Span) {
+	span.RecordError(e)
+	span.SetStatus(otelcodes.Error, e.message())
+	span.SetAttributes(
+		attribute.String("error.type", e.ErrorType),
+		attribute.Int("error.code", e.ErrorCode),
+	)
+}
+
+// RecordError records err on the span stored in ctx. An ApiError in err's chain is
+// recorded with RecordOnSpan; any other error is recorded as is. A nil err is ignored.
+func RecordError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	var apiError *ApiError
+	if errors.As(err, &apiError) && apiError != nil {
+		apiError.RecordOnSpan(span)
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(otelcodes.Error, err.Error())
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpan runs fn inside a span and returns the ended span.
+func recordSpan(t *testing.T, fn func(ctx context.Context)) sdktrace.ReadOnlySpan {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("errors").Start(context.Background(), "request")
+	fn(ctx)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	return spans[0]
+}
+
+func TestRecordError(t *testing.T) {
+	// Act
+	span := recordSpan(t, func(ctx context.Context) {
+		RecordError(ctx, NotFound("User not found"))
+	})
+
+	// Assert
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("expected status %s, got %s", otelcodes.Error, span.Status().Code)
+	}
+	if span.Status().Description != "User not found" {
+		t.Errorf("expected status description %s, got %s", "User not found", span.Status().Description)
+	}
+	attributes := attribute.NewSet(span.Attributes()...)
+	if value, _ := attributes.Value("error.type"); value.AsString() != NotFoundErrorType {
+		t.Errorf("expected error.type %s, got %s", NotFoundErrorType, value.AsString())
+	}
+	if value, _ := attributes.Value("error.code"); value.AsInt64() != 404 {
+		t.Errorf("expected error.code %d, got %d", 404, value.AsInt64())
+	}
+	if len(span.Events()) != 1 || span.Events()[0].Name != "exception" {
+		t.Errorf("expected one exception event, got %v", span.Events())
+	}
+}
+
+func TestRecordErrorWithPlainError(t *testing.T) {
+	span := recordSpan(t, func(ctx context.Context) {
+		RecordError(ctx, errors.New("connection refused"))
+	})
+
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("expected status %s, got %s", otelcodes.Error, span.Status().Code)
+	}
+	attributes := attribute.NewSet(span.Attributes()...)
+	if _, exists := attributes.Value("error.type"); exists {
+		t.Error("expected no error.type attribute for a plain error")
+	}
+}
+
+func TestRecordErrorWithNil(t *testing.T) {
+	span := recordSpan(t, func(ctx context.Context) {
+		RecordError(ctx, nil)
+	})
+
+	if span.Status().Code != otelcodes.Unset {
+		t.Errorf("expected status %s, got %s", otelcodes.Unset, span.Status().Code)
+	}
+}
@@ -0,0 +1,26 @@
+package errors
+
+import "sync"
+
+// apiErrorPool holds released ApiErrors for reuse by AcquireApiError.
+var apiErrorPool = sync.Pool{
+	New: func() any { return new(ApiError) },
+}
+
+// AcquireApiError returns a zeroed ApiError from a shared pool, for hot paths where
+// allocating every error adds up. Fill it in directly and hand it back with
+// ReleaseApiError once it has been written or logged.
+func AcquireApiError() *ApiError {
+	return apiErrorPool.Get().(*ApiError)
+}
+
+// ReleaseApiError resets e and returns it to the pool used by AcquireApiError. The
+// caller must not use e, or keep any reference to it, after the release: it may be
+// handed out again at any time. A nil e is ignored.
+func ReleaseApiError(e *ApiError) {
+	if e == nil {
+		return
+	}
+	*e = ApiError{}
+	apiErrorPool.Put(e)
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAcquireApiErrorIsZeroed(t *testing.T) {
+	// Arrange
+	apiError := AcquireApiError()
+	apiError.ErrorType = NotFoundErrorType
+	apiError.Message = "User not found"
+	apiError.ErrorCode = http.StatusNotFound
+	apiError.Details = []string{"id must be positive"}
+	apiError.Metadata = map[string]any{"user_id": 42}
+	apiError.InnerError = errors.New("no rows")
+	apiError.RetryAfter = time.Second
+	apiError.Timestamp = time.Now()
+	WithStackTrace()(apiError)
+	WithRetryable(true)(apiError)
+
+	// Act
+	ReleaseApiError(apiError)
+	acquired := AcquireApiError()
+	t.Cleanup(func() { ReleaseApiError(acquired) })
+
+	// Assert
+	if acquired.ErrorType != "" || acquired.Message != "" || acquired.ErrorCode != 0 {
+		t.Errorf("expected zeroed error, got %#v", acquired)
+	}
+	if acquired.Details != nil || acquired.Metadata != nil || acquired.InnerError != nil {
+		t.Errorf("expected no details, metadata or inner error, got %#v", acquired)
+	}
+	if acquired.RetryAfter != 0 || !acquired.Timestamp.IsZero() {
+		t.Errorf("expected no retry hint or timestamp, got %#v", acquired)
+	}
+	if acquired.stack != nil || acquired.retryable != nil {
+		t.Errorf("expected no stack or retryable flag, got %#v", acquired)
+	}
+}
+
+func TestReleaseApiErrorIgnoresNil(t *testing.T) {
+	ReleaseApiError(nil)
+
+	if apiError := AcquireApiError(); apiError == nil {
+		t.Error("expected a non-nil ApiError")
+	}
+}
+
+func BenchmarkNewApiError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = NewApiError(NotFoundErrorType, "User not found")
+	}
+}
+
+func BenchmarkAcquireApiError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		apiError := AcquireApiError()
+		apiError.ErrorType = NotFoundErrorType
+		apiError.Message = "User not found"
+		apiError.ErrorCode = http.StatusNotFound
+		ReleaseApiError(apiError)
+	}
+}
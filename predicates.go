@@ -0,0 +1,84 @@
+package errors
+
+import "errors"
+
+// isErrorType reports whether err has an ApiError of the given type in its chain.
+func isErrorType(err error, errorType string) bool {
+	var apiError *ApiError
+	if !errors.As(err, &apiError) || apiError == nil {
+		return false
+	}
+	return apiError.ErrorType == errorType
+}
+
+// IsNotFound reports whether err is a NotFoundError ApiError.
+func IsNotFound(err error) bool {
+	return isErrorType(err, NotFoundErrorType)
+}
+
+// IsInternalServer reports whether err is an InternalServerError ApiError.
+func IsInternalServer(err error) bool {
+	return isErrorType(err, InternalServerErrorType)
+}
+
+// IsBadRequest reports whether err is a BadRequestError ApiError.
+func IsBadRequest(err error) bool {
+	return isErrorType(err, BadRequestErrorType)
+}
+
+// IsUnauthorized reports whether err is an UnauthorizedError ApiError.
+func IsUnauthorized(err error) bool {
+	return isErrorType(err, UnauthorizedErrorType)
+}
+
+// IsForbidden reports whether err is a ForbiddenError ApiError.
+func IsForbidden(err error) bool {
+	return isErrorType(err, ForbiddenErrorType)
+}
+
+// IsConflict reports whether err is a ConflictError ApiError.
+func IsConflict(err error) bool {
+	return isErrorType(err, ConflictErrorType)
+}
+
+// IsMethodNotAllowed reports whether err is a MethodNotAllowedError ApiError.
+func IsMethodNotAllowed(err error) bool {
+	return isErrorType(err, MethodNotAllowedErrorType)
+}
+
+// IsRequestTimeout reports whether err is a RequestTimeoutError ApiError.
+func IsRequestTimeout(err error) bool {
+	return isErrorType(err, RequestTimeoutErrorType)
+}
+
+// IsUnprocessableEntity reports whether err is an UnprocessableEntityError ApiError.
+func IsUnprocessableEntity(err error) bool {
+	return isErrorType(err, UnprocessableEntityErrorType)
+}
+
+// IsTooManyRequests reports whether err is a TooManyRequestsError ApiError.
+func IsTooManyRequests(err error) bool {
+	return isErrorType(err, TooManyRequestsErrorType)
+}
+
+// IsClientError reports whether the error code is in the 4xx range.
+func (e *ApiError) IsClientError() bool {
+	return e.ErrorCode >= 400 && e.ErrorCode < 500
+}
+
+// IsServerError reports whether the error code is in the 5xx range.
+func (e *ApiError) IsServerError() bool {
+	return e.ErrorCode >= 500 && e.ErrorCode < 600
+}
+
+// IsClientError reports whether err has an ApiError with a 4xx code in its chain.
+func IsClientError(err error) bool {
+	var apiError *ApiError
+	return errors.As(err, &apiError) && apiError != nil && apiError.IsClientError()
+}
+
+// IsServerError reports whether err has an ApiError with a 5xx code in its chain.
+func IsServerError(err error) bool {
+	var apiError *ApiError
+	return errors.As(err, &apiError) && apiError != nil && apiError.IsServerError()
+}
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	tests := []struct {
+		name      string
+		predicate func(error) bool
+		err       *ApiError
+	}{
+		{"IsNotFound", IsNotFound, NotFound("missing").ApiError},
+		{"IsInternalServer", IsInternalServer, InternalServer("boom").ApiError},
+		{"IsBadRequest", IsBadRequest, BadRequest("bad").ApiError},
+		{"IsUnauthorized", IsUnauthorized, Unauthorized("who are you").ApiError},
+		{"IsForbidden", IsForbidden, Forbidden("no").ApiError},
+		{"IsConflict", IsConflict, Conflict("taken").ApiError},
+		{"IsMethodNotAllowed", IsMethodNotAllowed, MethodNotAllowed("nope").ApiError},
+		{"IsRequestTimeout", IsRequestTimeout, RequestTimeout("slow").ApiError},
+		{"IsUnprocessableEntity", IsUnprocessableEntity, UnprocessableEntity("invalid").ApiError},
+		{"IsTooManyRequests", IsTooManyRequests, TooManyRequests("slow down").ApiError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.predicate(tt.err) {
+				t.Errorf("expected %s to match %s", tt.name, tt.err.ErrorType)
+			}
+			if tt.predicate(NewApiError("OtherError", "other")) {
+				t.Errorf("expected %s not to match a different error type", tt.name)
+			}
+			if tt.predicate(nil) {
+				t.Errorf("expected %s to return false for nil", tt.name)
+			}
+			if tt.predicate(errors.New("plain error")) {
+				t.Errorf("expected %s to return false for a non-ApiError", tt.name)
+			}
+		})
+	}
+}
+
+func TestPredicatesMatchDeeplyWrappedApiError(t *testing.T) {
+	// Arrange: wrap a NotFound ApiError in several layers
+	err := fmt.Errorf("handler: %w", fmt.Errorf("service: %w", fmt.Errorf("repository: %w", NotFound("User not found"))))
+
+	// Assert
+	if !IsNotFound(err) {
+		t.Error("expected IsNotFound to match a deeply wrapped ApiError")
+	}
+	if IsConflict(err) {
+		t.Error("expected IsConflict not to match a wrapped NotFound ApiError")
+	}
+}
+
+func TestPredicatesMatchOutermostApiError(t *testing.T) {
+	err := InternalServer("boom", WithInternalError(NotFound("User not found")))
+
+	if !IsInternalServer(err) {
+		t.Error("expected IsInternalServer to match the outermost ApiError")
+	}
+}
+
+func TestClientAndServerErrorClassifiers(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedClient bool
+		expectedServer bool
+	}{
+		{"not found", NotFound("User not found"), true, false},
+		{"internal server error", InternalServer("boom"), false, true},
+		{"wrapped not found", fmt.Errorf("load: %w", NotFound("User not found")), true, false},
+		{"plain error", errors.New("boom"), false, false},
+		{"nil", nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if IsClientError(tt.err) != tt.expectedClient {
+				t.Errorf("expected IsClientError %t, got %t", tt.expectedClient, IsClientError(tt.err))
+			}
+			if IsServerError(tt.err) != tt.expectedServer {
+				t.Errorf("expected IsServerError %t, got %t", tt.expectedServer, IsServerError(tt.err))
+			}
+		})
+	}
+}
+
+func TestApiErrorClassifierMethods(t *testing.T) {
+	if !NotFound("User not found").IsClientError() {
+		t.Error("expected a 404 to be a client error")
+	}
+	if NotFound("User not found").IsServerError() {
+		t.Error("expected a 404 not to be a server error")
+	}
+	if !InternalServer("boom").IsServerError() {
+		t.Error("expected a 500 to be a server error")
+	}
+}
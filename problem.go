@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details documents.
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBaseURI prefixes the ErrorType to build the problem "type" URI reference.
+const problemTypeBaseURI = "/errors/"
+
+// problemDetails is the RFC 7807 representation of an ApiError.
+type problemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// WithInstance sets the RFC 7807 "instance" URI identifying this occurrence of the problem.
+func WithInstance(uri string) ErrorOption {
+	return func(ae *ApiError) {
+		ae.Instance = uri
+	}
+}
+
+// ProblemJSON serializes the ApiError as an RFC 7807 application/problem+json document.
+// The title is the registry's default message for the type, falling back to the HTTP status text.
+func (e *ApiError) ProblemJSON() ([]byte, error) {
+	return json.Marshal(e.problemDetails())
+}
+
+// problemDetails returns the RFC 7807 representation of the ApiError.
+func (e *ApiError) problemDetails() problemDetails {
+	title := http.StatusText(e.ErrorCode)
+	if errType, exists := LookupErrorType(e.ErrorType); exists {
+		title = errType.Message
+	}
+	return problemDetails{
+		Type:     problemTypeBaseURI + e.ErrorType,
+		Title:    title,
+		Status:   e.ErrorCode,
+		Detail:   e.message(),
+		Instance: e.Instance,
+	}
+}
+
+// ProblemJSON serializes the MultiError as an RFC 7807 document describing its most
+// severe error, with every aggregated error listed under the "errors" extension member.
+func (m *MultiError) ProblemJSON() ([]byte, error) {
+	var problem struct {
+		problemDetails
+		Errors []problemDetails `json:"errors"`
+	}
+	problem.Errors = []problemDetails{}
+	if severe := m.mostSevere(); severe != nil {
+		problem.problemDetails = severe.problemDetails()
+	}
+	for _, err := range m.errs {
+		problem.Errors = append(problem.Errors, err.problemDetails())
+	}
+	return json.Marshal(problem)
+}
@@ -0,0 +1,34 @@
+package errors
+
+import "testing"
+
+func TestProblemJSON(t *testing.T) {
+	// Arrange: Create a NotFound ApiError with an instance
+	apiError := NewApiError(NotFoundErrorType, "User not found", WithInstance("/users/42"))
+
+	// Act: Render it as problem+json
+	jsonData, err := apiError.ProblemJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal problem details: %v", err)
+	}
+
+	// Assert: Check the exact document shape
+	expectedJSON := `{"type":"/errors/NotFoundError","title":"Resource not found","status":404,"detail":"User not found","instance":"/users/42"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+}
+
+func TestProblemJSONOmitsEmptyInstanceAndUsesStatusTextForUnknownType(t *testing.T) {
+	apiError := &ApiError{ErrorType: "UnknownError", Message: "Something broke", ErrorCode: 500}
+
+	jsonData, err := apiError.ProblemJSON()
+	if err != nil {
+		t.Fatalf("failed to marshal problem details: %v", err)
+	}
+
+	expectedJSON := `{"type":"/errors/UnknownError","title":"Internal Server Error","status":500,"detail":"Something broke"}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+}
@@ -0,0 +1,570 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrorType represents an error type configuration. Use keyed literals such as
+// ErrorType{ErrorCode: 404, Message: "Not found"}: fields have been added after ErrorCode
+// and Message, so unkeyed literals like ErrorType{404, "Not found"} no longer compile.
+type ErrorType struct {
+	ErrorCode int
+	Message   string
+	// AppCode is the default application code, such as "USER_NOT_FOUND", of errors of this type.
+	AppCode string
+	// HelpURL is the default documentation link of errors of this type.
+	HelpURL string
+	// Category overrides the category derived from ErrorCode.
+	Category Category
+}
+
+// ErrorRegistry is a map of error types and their properties.
+//
+// Deprecated: ErrorRegistry is kept for backward compatibility and reflects every
+// registered type. Types written to it directly are seen by every lookup, after the
+// registered ones, but accessing it directly is not safe for concurrent use.
+// Use LookupErrorType and RegisterErrorType instead.
+var ErrorRegistry = builtinRegistry()
+
+// builtinRegistry returns a fresh copy of the built-in error type definitions.
+func builtinRegistry() map[string]ErrorType {
+	return map[string]ErrorType{
+		NotFoundErrorType:                   {ErrorCode: http.StatusNotFound, Message: "Resource not found"},
+		InternalServerErrorType:             {ErrorCode: http.StatusInternalServerError, Message: "Internal server error"},
+		BadRequestErrorType:                 {ErrorCode: http.StatusBadRequest, Message: "Bad request"},
+		UnauthorizedErrorType:               {ErrorCode: http.StatusUnauthorized, Message: "Unauthorized access"},
+		ForbiddenErrorType:                  {ErrorCode: http.StatusForbidden, Message: "Forbidden"},
+		ConflictErrorType:                   {ErrorCode: http.StatusConflict, Message: "Conflict occurred"},
+		MethodNotAllowedErrorType:           {ErrorCode: http.StatusMethodNotAllowed, Message: "Method not allowed"},
+		RequestTimeoutErrorType:             {ErrorCode: http.StatusRequestTimeout, Message: "Request timed out"},
+		UnprocessableEntityErrorType:        {ErrorCode: http.StatusUnprocessableEntity, Message: "Unprocessable entity"},
+		TooManyRequestsErrorType:            {ErrorCode: http.StatusTooManyRequests, Message: "Too many requests"},
+		ClientClosedRequestErrorType:        {ErrorCode: StatusClientClosedRequest, Message: "Client closed request"},
+		MovedPermanentlyErrorType:           {ErrorCode: http.StatusMovedPermanently, Message: "Moved permanently"},
+		FoundErrorType:                      {ErrorCode: http.StatusFound, Message: "Found"},
+		PaymentRequiredErrorType:            {ErrorCode: http.StatusPaymentRequired, Message: "Payment required"},
+		GoneErrorType:                       {ErrorCode: http.StatusGone, Message: "Resource no longer available"},
+		UnsupportedMediaTypeErrorType:       {ErrorCode: http.StatusUnsupportedMediaType, Message: "Unsupported media type"},
+		UnavailableForLegalReasonsErrorType: {ErrorCode: http.StatusUnavailableForLegalReasons, Message: "Unavailable for legal reasons"},
+		GenericErrorType:                    {ErrorCode: http.StatusInternalServerError, Message: genericErrorMessage},
+		// You can add more error types as needed...
+	}
+}
+
+// builtinErrorTypes lists the built-in types in registration order.
+var builtinErrorTypes = []string{
+	NotFoundErrorType,
+	InternalServerErrorType,
+	BadRequestErrorType,
+	UnauthorizedErrorType,
+	ForbiddenErrorType,
+	ConflictErrorType,
+	MethodNotAllowedErrorType,
+	RequestTimeoutErrorType,
+	UnprocessableEntityErrorType,
+	TooManyRequestsErrorType,
+	ClientClosedRequestErrorType,
+	MovedPermanentlyErrorType,
+	FoundErrorType,
+	PaymentRequiredErrorType,
+	GoneErrorType,
+	UnsupportedMediaTypeErrorType,
+	UnavailableForLegalReasonsErrorType,
+	GenericErrorType,
+}
+
+var (
+	// ErrEmptyErrorTypeName is returned when registering an error type without a name.
+	ErrEmptyErrorTypeName = errors.New("error type name is empty")
+	// ErrInvalidErrorCode is returned when registering an error type with a code outside 100-599.
+	ErrInvalidErrorCode = errors.New("error code is not a valid HTTP status code")
+	// ErrBuiltinErrorType is returned when replacing a built-in error type without AllowBuiltinOverride.
+	ErrBuiltinErrorType = errors.New("error type is built in")
+	// ErrUnknownErrorType is returned when strict unmarshaling meets an unregistered error type.
+	ErrUnknownErrorType = errors.New("error type is not registered")
+)
+
+// RegisterOption configures a registration made with RegisterErrorType or RegisterErrorTypeChecked.
+type RegisterOption func(*registration)
+
+// registration collects the settings of a single error type registration.
+type registration struct {
+	errorType     ErrorType
+	allowOverride bool
+}
+
+// AllowBuiltinOverride lets RegisterErrorTypeChecked replace a built-in error type.
+func AllowBuiltinOverride() RegisterOption {
+	return func(r *registration) {
+		r.allowOverride = true
+	}
+}
+
+// WithDefaultAppCode sets the application code given to errors of the registered type.
+func WithDefaultAppCode(code string) RegisterOption {
+	return func(r *registration) {
+		r.errorType.AppCode = code
+	}
+}
+
+// WithDefaultHelpURL sets the documentation link given to errors of the registered type.
+func WithDefaultHelpURL(url string) RegisterOption {
+	return func(r *registration) {
+		r.errorType.HelpURL = url
+	}
+}
+
+// WithCategory sets the category of errors of the registered type.
+func WithCategory(category Category) RegisterOption {
+	return func(r *registration) {
+		r.errorType.Category = category
+	}
+}
+
+// isBuiltinErrorType reports whether name is one of the built-in error types.
+func isBuiltinErrorType(name string) bool {
+	for _, builtin := range builtinErrorTypes {
+		if builtin == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRegistration checks that a registration has a name and a valid HTTP status code.
+func validateRegistration(name string, reg registration) error {
+	switch {
+	case name == "":
+		return ErrEmptyErrorTypeName
+	case reg.errorType.ErrorCode < 100 || reg.errorType.ErrorCode > 599:
+		return fmt.Errorf("%w: %s has code %d", ErrInvalidErrorCode, name, reg.errorType.ErrorCode)
+	case isBuiltinErrorType(name) && !reg.allowOverride:
+		return fmt.Errorf("%w: %s", ErrBuiltinErrorType, name)
+	}
+	return nil
+}
+
+// Registry stores error type definitions. Implement it to load definitions from a
+// database or configuration service and install it with SetRegistry. Implementations
+// must be safe for concurrent use.
+type Registry interface {
+	// Lookup returns the definition registered for name.
+	Lookup(name string) (ErrorType, bool)
+	// Register adds or replaces the definition of name.
+	Register(name string, errorType ErrorType)
+	// Unregister removes the definition of name, if any.
+	Unregister(name string)
+	// Range calls fn for each definition in registration order until fn returns false.
+	Range(fn func(name string, errorType ErrorType) bool)
+}
+
+// LocalizedRegistry is a Registry that also stores localized messages. When the Registry
+// set with SetRegistry does not implement it, localized messages are kept in the
+// built-in in-memory store.
+type LocalizedRegistry interface {
+	Registry
+	// RegisterLocalized stores the message of errorType in the language lang.
+	RegisterLocalized(errorType, lang, message string)
+	// LookupLocalized returns the message of errorType in lang.
+	LookupLocalized(errorType, lang string) (string, bool)
+}
+
+// NewRegistry returns an in-memory Registry holding the built-in error types, the same
+// kind of store the package uses by default.
+func NewRegistry() Registry {
+	return &registry{
+		types: builtinRegistry(),
+		order: append([]string(nil), builtinErrorTypes...),
+	}
+}
+
+// customRegistry holds the Registry set with SetRegistry; nil means defaultRegistry.
+var customRegistry atomic.Pointer[Registry]
+
+// SetRegistry makes every registry operation of the package, from LookupErrorType and
+// the constructors to ErrorTypeForCode, OpenAPIResponses and the snapshot functions,
+// use r instead of the built-in in-memory registry. The deprecated ErrorRegistry map
+// only reflects the built-in registry. A nil r, or ResetRegistry, restores the built-in
+// registry.
+func SetRegistry(r Registry) {
+	if r == nil {
+		customRegistry.Store(nil)
+		return
+	}
+	customRegistry.Store(&r)
+}
+
+// activeRegistry returns the Registry set with SetRegistry, or defaultRegistry.
+func activeRegistry() Registry {
+	if r := customRegistry.Load(); r != nil {
+		return *r
+	}
+	return defaultRegistry
+}
+
+// activeLocalizedRegistry returns the active registry if it stores localized messages,
+// or defaultRegistry otherwise.
+func activeLocalizedRegistry() LocalizedRegistry {
+	if r, ok := activeRegistry().(LocalizedRegistry); ok {
+		return r
+	}
+	return defaultRegistry
+}
+
+// registry guards the error type definitions with a read/write lock.
+type registry struct {
+	mu        sync.RWMutex
+	types     map[string]ErrorType
+	order     []string
+	localized map[string]map[string]string
+}
+
+// make sure registry implements LocalizedRegistry in compile time
+var _ LocalizedRegistry = (*registry)(nil)
+
+// defaultRegistry shares its map with ErrorRegistry so legacy readers keep seeing registered types.
+var defaultRegistry = &registry{
+	types: ErrorRegistry,
+	order: append([]string(nil), builtinErrorTypes...),
+}
+
+// Unregister implements Registry.
+func (r *registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.types[name]; !exists {
+		return
+	}
+	delete(r.types, name)
+	for i, registered := range r.order {
+		if registered == name {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// reset restores the built-in definitions in place, keeping ErrorRegistry pointing at the same map.
+func (r *registry) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.types)
+	for name, errorType := range builtinRegistry() {
+		r.types[name] = errorType
+	}
+	r.order = append(r.order[:0], builtinErrorTypes...)
+	r.localized = nil
+}
+
+// RegistrySnapshot is an opaque copy of the registry taken by SnapshotRegistry.
+type RegistrySnapshot struct {
+	types     map[string]ErrorType
+	order     []string
+	localized map[string]map[string]string
+}
+
+// snapshot copies the registered types, their order and the localized messages.
+func (r *registry) snapshot() RegistrySnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshot := RegistrySnapshot{
+		types: make(map[string]ErrorType, len(r.types)),
+		order: append([]string(nil), r.order...),
+	}
+	for name, errorType := range r.types {
+		snapshot.types[name] = errorType
+	}
+	if r.localized != nil {
+		snapshot.localized = copyLocalized(r.localized)
+	}
+	return snapshot
+}
+
+// snapshotLocalized copies the localized messages.
+func (r *registry) snapshotLocalized() map[string]map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.localized == nil {
+		return nil
+	}
+	return copyLocalized(r.localized)
+}
+
+// restoreLocalized replaces the localized messages with a copy of localized.
+func (r *registry) restoreLocalized(localized map[string]map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.localized = nil
+	if localized != nil {
+		r.localized = copyLocalized(localized)
+	}
+}
+
+// restore replaces the registry contents with a snapshot, keeping ErrorRegistry pointing at the same map.
+func (r *registry) restore(snapshot RegistrySnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.types)
+	for name, errorType := range snapshot.types {
+		r.types[name] = errorType
+	}
+	r.order = append(r.order[:0], snapshot.order...)
+	r.localized = nil
+	if snapshot.localized != nil {
+		r.localized = copyLocalized(snapshot.localized)
+	}
+}
+
+// copyLocalized returns a deep copy of localized messages keyed by error type and language.
+func copyLocalized(localized map[string]map[string]string) map[string]map[string]string {
+	copied := make(map[string]map[string]string, len(localized))
+	for errorType, messages := range localized {
+		copied[errorType] = make(map[string]string, len(messages))
+		for lang, message := range messages {
+			copied[errorType][lang] = message
+		}
+	}
+	return copied
+}
+
+// Lookup implements Registry.
+func (r *registry) Lookup(name string) (ErrorType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	errorType, exists := r.types[name]
+	return errorType, exists
+}
+
+// Register implements Registry.
+func (r *registry) Register(name string, errorType ErrorType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.types[name]; !exists {
+		r.order = append(r.order, name)
+	}
+	r.types[name] = errorType
+}
+
+// registerAll adds or replaces every type in names order while holding the lock once.
+func (r *registry) registerAll(names []string, types map[string]ErrorType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		if _, exists := r.types[name]; !exists {
+			r.order = append(r.order, name)
+		}
+		r.types[name] = types[name]
+	}
+}
+
+// LookupErrorType returns the registered configuration for an error type. It is safe for concurrent use.
+func LookupErrorType(name string) (ErrorType, bool) {
+	return activeRegistry().Lookup(name)
+}
+
+// RegisterErrorType adds or replaces an error type in the registry. It is safe for concurrent use.
+func RegisterErrorType(name string, errorCode int, message string, options ...RegisterOption) {
+	reg := registration{errorType: ErrorType{ErrorCode: errorCode, Message: message}}
+	for _, option := range options {
+		option(&reg)
+	}
+	activeRegistry().Register(name, reg.errorType)
+}
+
+// Range implements Registry. Types written directly to the deprecated ErrorRegistry map
+// follow the registered ones, sorted by name. fn is called on a copy, so it may use the
+// registry.
+func (r *registry) Range(fn func(name string, errorType ErrorType) bool) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.types))
+	ordered := make(map[string]bool, len(r.order))
+	for _, name := range r.order {
+		if _, exists := r.types[name]; exists {
+			names = append(names, name)
+			ordered[name] = true
+		}
+	}
+	var legacy []string
+	for name := range r.types {
+		if !ordered[name] {
+			legacy = append(legacy, name)
+		}
+	}
+	sort.Strings(legacy)
+	names = append(names, legacy...)
+	types := make([]ErrorType, len(names))
+	for i, name := range names {
+		types[i] = r.types[name]
+	}
+	r.mu.RUnlock()
+	for i, name := range names {
+		if !fn(name, types[i]) {
+			return
+		}
+	}
+}
+
+// orderedErrorTypes returns the definitions of the active registry in registration order.
+func orderedErrorTypes() []ErrorType {
+	var types []ErrorType
+	activeRegistry().Range(func(_ string, errorType ErrorType) bool {
+		types = append(types, errorType)
+		return true
+	})
+	return types
+}
+
+// defaultErrorType returns the type used for unknown error types, falling back to the
+// built-in GenericError definition when the configured default is not registered.
+func defaultErrorType() (string, ErrorType) {
+	name := GenericErrorType
+	if configured := defaultErrorTypeName.Load(); configured != nil {
+		name = *configured
+	}
+	if errorType, exists := LookupErrorType(name); exists {
+		return name, errorType
+	}
+	return GenericErrorType, ErrorType{ErrorCode: http.StatusInternalServerError, Message: genericErrorMessage}
+}
+
+// ErrorTypeForCode returns the error type registered for an HTTP status code.
+// When several types share a code, the one registered first wins, so built-in
+// types always take precedence over custom types registered later.
+func ErrorTypeForCode(code int) (string, bool) {
+	found := ""
+	activeRegistry().Range(func(name string, errorType ErrorType) bool {
+		if errorType.ErrorCode == code {
+			found = name
+			return false
+		}
+		return true
+	})
+	return found, found != ""
+}
+
+// RegisterErrorTypeChecked is like RegisterErrorType but rejects an empty name, a code
+// outside 100-599, and replacing a built-in type unless AllowBuiltinOverride is passed.
+func RegisterErrorTypeChecked(name string, errorCode int, message string, options ...RegisterOption) error {
+	reg := registration{errorType: ErrorType{ErrorCode: errorCode, Message: message}}
+	for _, option := range options {
+		option(&reg)
+	}
+	if err := validateRegistration(name, reg); err != nil {
+		return err
+	}
+	activeRegistry().Register(name, reg.errorType)
+	return nil
+}
+
+// RegisterErrorTypes registers every type in types at once. Each entry is validated like
+// RegisterErrorTypeChecked; if any is invalid, nothing is registered and the returned
+// error lists every invalid entry.
+func RegisterErrorTypes(types map[string]ErrorType) error {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := validateRegistration(name, registration{errorType: types[name]}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	target := activeRegistry()
+	if r, ok := target.(*registry); ok {
+		r.registerAll(names, types)
+		return nil
+	}
+	for _, name := range names {
+		target.Register(name, types[name])
+	}
+	return nil
+}
+
+// UnregisterErrorType removes an error type from the registry. It is safe for concurrent use.
+func UnregisterErrorType(name string) {
+	activeRegistry().Unregister(name)
+}
+
+// ResetRegistry removes every custom error type and restores the built-in defaults,
+// switching back from a Registry set with SetRegistry. It is safe for concurrent use
+// and is intended for test teardown.
+func ResetRegistry() {
+	SetRegistry(nil)
+	defaultRegistry.reset()
+}
+
+// SnapshotRegistry returns a copy of the current registry, including localized messages,
+// that RestoreRegistry can bring back later. It is safe for concurrent use. Localized
+// messages kept by a custom LocalizedRegistry are left to that registry.
+func SnapshotRegistry() RegistrySnapshot {
+	target := activeRegistry()
+	if r, ok := target.(*registry); ok {
+		return r.snapshot()
+	}
+	snapshot := RegistrySnapshot{types: make(map[string]ErrorType)}
+	target.Range(func(name string, errorType ErrorType) bool {
+		snapshot.types[name] = errorType
+		snapshot.order = append(snapshot.order, name)
+		return true
+	})
+	if _, ok := target.(LocalizedRegistry); !ok {
+		snapshot.localized = defaultRegistry.snapshotLocalized()
+	}
+	return snapshot
+}
+
+// RestoreRegistry replaces the registry with a snapshot taken by SnapshotRegistry. It is
+// safe for concurrent use. Restoring the zero RegistrySnapshot leaves the registry empty.
+func RestoreRegistry(snapshot RegistrySnapshot) {
+	target := activeRegistry()
+	if r, ok := target.(*registry); ok {
+		r.restore(snapshot)
+		return
+	}
+	var names []string
+	target.Range(func(name string, _ ErrorType) bool {
+		names = append(names, name)
+		return true
+	})
+	for _, name := range names {
+		target.Unregister(name)
+	}
+	for _, name := range snapshot.order {
+		target.Register(name, snapshot.types[name])
+	}
+	if _, ok := target.(LocalizedRegistry); !ok {
+		defaultRegistry.restoreLocalized(snapshot.localized)
+	}
+}
+
+// RegisteredTypes returns the names of all registered error types, sorted alphabetically.
+func RegisteredTypes() []string {
+	names := []string{}
+	activeRegistry().Range(func(name string, _ ErrorType) bool {
+		names = append(names, name)
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// AllErrorTypes returns a copy of every registered error type; changing it does not affect the registry.
+func AllErrorTypes() map[string]ErrorType {
+	types := make(map[string]ErrorType)
+	activeRegistry().Range(func(name string, errorType ErrorType) bool {
+		types[name] = errorType
+		return true
+	})
+	return types
+}
@@ -0,0 +1,573 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLookupErrorType(t *testing.T) {
+	errorType, exists := LookupErrorType(NotFoundErrorType)
+	if !exists {
+		t.Fatalf("expected error type %s to be registered", NotFoundErrorType)
+	}
+	if errorType.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, errorType.ErrorCode)
+	}
+
+	if _, exists := LookupErrorType("MissingError"); exists {
+		t.Error("expected unregistered error type lookup to return false")
+	}
+}
+
+func TestRegisterErrorTypeIsVisibleThroughLookup(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+
+	errorType, exists := LookupErrorType("PreconditionFailedError")
+	if !exists {
+		t.Fatal("expected registered error type to be found")
+	}
+	if errorType.ErrorCode != http.StatusPreconditionFailed {
+		t.Errorf("expected error code %d, got %d", http.StatusPreconditionFailed, errorType.ErrorCode)
+	}
+}
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			RegisterErrorType(fmt.Sprintf("ConcurrentError%d", i), http.StatusTeapot, "I'm a teapot")
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = NewApiError(NotFoundErrorType, "User not found")
+		}()
+	}
+	wg.Wait()
+
+	if _, exists := LookupErrorType("ConcurrentError49"); !exists {
+		t.Error("expected concurrently registered error type to be found")
+	}
+}
+
+func TestErrorTypeForCode(t *testing.T) {
+	errorType, exists := ErrorTypeForCode(http.StatusNotFound)
+	if !exists {
+		t.Fatalf("expected a type for code %d", http.StatusNotFound)
+	}
+	if errorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, errorType)
+	}
+
+	if errorType, exists := ErrorTypeForCode(http.StatusLoopDetected); exists {
+		t.Errorf("expected no type for code %d, got %s", http.StatusLoopDetected, errorType)
+	}
+}
+
+func TestErrorTypeForCodePrefersFirstRegistered(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("MissingUserError", http.StatusNotFound, "User not found")
+
+	errorType, _ := ErrorTypeForCode(http.StatusNotFound)
+	if errorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, errorType)
+	}
+}
+
+func TestUnregisterErrorType(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+
+	UnregisterErrorType("PreconditionFailedError")
+
+	if _, exists := LookupErrorType("PreconditionFailedError"); exists {
+		t.Error("expected unregistered error type to be removed")
+	}
+	if _, exists := ErrorTypeForCode(http.StatusPreconditionFailed); exists {
+		t.Error("expected unregistered error type to be removed from the reverse lookup")
+	}
+}
+
+func TestResetRegistry(t *testing.T) {
+	// Arrange: register a custom type and override a built-in
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+	RegisterErrorType(NotFoundErrorType, http.StatusGone, "Gone")
+
+	// Act
+	ResetRegistry()
+
+	// Assert: the custom type is gone and built-ins are restored
+	if _, exists := LookupErrorType("PreconditionFailedError"); exists {
+		t.Error("expected custom error type to be removed after reset")
+	}
+	for _, name := range builtinErrorTypes {
+		if _, exists := LookupErrorType(name); !exists {
+			t.Errorf("expected built-in error type %s after reset", name)
+		}
+	}
+	if errorType, _ := LookupErrorType(NotFoundErrorType); errorType.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, errorType.ErrorCode)
+	}
+	if _, exists := ErrorRegistry["PreconditionFailedError"]; exists {
+		t.Error("expected ErrorRegistry to reflect the reset")
+	}
+}
+
+func TestErrorRegistryDirectWrites(t *testing.T) {
+	// Arrange: write to the deprecated map directly
+	t.Cleanup(ResetRegistry)
+	ErrorRegistry["LegacyError"] = ErrorType{ErrorCode: http.StatusTeapot, Message: "Legacy"}
+
+	// Act
+	errorType, exists := ErrorTypeForCode(http.StatusTeapot)
+
+	// Assert
+	if !exists || errorType != "LegacyError" {
+		t.Errorf("expected error type %s, got %s", "LegacyError", errorType)
+	}
+	registered := RegisteredTypes()
+	if !slices.Contains(registered, "LegacyError") {
+		t.Errorf("expected registered types to contain %s, got %v", "LegacyError", registered)
+	}
+	if _, exists := AllErrorTypes()["LegacyError"]; !exists {
+		t.Errorf("expected all error types to contain %s", "LegacyError")
+	}
+	if _, exists := OpenAPIResponses()["418"]; !exists {
+		t.Errorf("expected an OpenAPI response for code %d", http.StatusTeapot)
+	}
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	ResetRegistry()
+
+	expected := append([]string(nil), builtinErrorTypes...)
+	sort.Strings(expected)
+
+	registered := RegisteredTypes()
+	if len(registered) != len(expected) {
+		t.Fatalf("expected %d registered types, got %d: %v", len(expected), len(registered), registered)
+	}
+	for i, name := range expected {
+		if registered[i] != name {
+			t.Errorf("expected type %s at position %d, got %s", name, i, registered[i])
+		}
+	}
+}
+
+func TestAllErrorTypesReturnsDefensiveCopy(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	types := AllErrorTypes()
+	if types[NotFoundErrorType].ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, types[NotFoundErrorType].ErrorCode)
+	}
+
+	types[NotFoundErrorType] = ErrorType{ErrorCode: http.StatusGone, Message: "Gone"}
+	delete(types, BadRequestErrorType)
+
+	if errorType, _ := LookupErrorType(NotFoundErrorType); errorType.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected registry to be unchanged, got error code %d", errorType.ErrorCode)
+	}
+	if _, exists := LookupErrorType(BadRequestErrorType); !exists {
+		t.Error("expected registry to still contain BadRequestError")
+	}
+}
+
+func TestRegisterErrorTypeChecked(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	if err := RegisterErrorTypeChecked("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed"); err != nil {
+		t.Fatalf("expected valid registration to succeed, got %v", err)
+	}
+	if _, exists := LookupErrorType("PreconditionFailedError"); !exists {
+		t.Error("expected registered error type to be found")
+	}
+}
+
+func TestRegisterErrorTypeCheckedRejectsInvalidInput(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	tests := []struct {
+		name      string
+		typeName  string
+		code      int
+		options   []RegisterOption
+		expectErr error
+	}{
+		{"empty name", "", http.StatusTeapot, nil, ErrEmptyErrorTypeName},
+		{"code 99", "TooLowError", 99, nil, ErrInvalidErrorCode},
+		{"code 600", "TooHighError", 600, nil, ErrInvalidErrorCode},
+		{"overwrite built-in", NotFoundErrorType, http.StatusGone, nil, ErrBuiltinErrorType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterErrorTypeChecked(tt.typeName, tt.code, "message", tt.options...)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+
+	if errorType, _ := LookupErrorType(NotFoundErrorType); errorType.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected built-in to be unchanged, got error code %d", errorType.ErrorCode)
+	}
+}
+
+func TestRegisterErrorTypeCheckedAllowsBuiltinOverride(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	if err := RegisterErrorTypeChecked(NotFoundErrorType, http.StatusGone, "Gone", AllowBuiltinOverride()); err != nil {
+		t.Fatalf("expected override to succeed, got %v", err)
+	}
+	if errorType, _ := LookupErrorType(NotFoundErrorType); errorType.ErrorCode != http.StatusGone {
+		t.Errorf("expected error code %d, got %d", http.StatusGone, errorType.ErrorCode)
+	}
+}
+
+func TestRegisterErrorTypeWithDefaultAppCode(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	RegisterErrorType("MissingUserError", http.StatusNotFound, "User not found", WithDefaultAppCode("USER_NOT_FOUND"))
+
+	if errorType, _ := LookupErrorType("MissingUserError"); errorType.AppCode != "USER_NOT_FOUND" {
+		t.Errorf("expected app code %s, got %s", "USER_NOT_FOUND", errorType.AppCode)
+	}
+}
+
+func TestRegisterErrorTypeWithDefaultHelpURL(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	RegisterErrorType("MissingUserError", http.StatusNotFound, "User not found", WithDefaultHelpURL("https://docs.example.com/errors/missing-user"))
+
+	if errorType, _ := LookupErrorType("MissingUserError"); errorType.HelpURL != "https://docs.example.com/errors/missing-user" {
+		t.Errorf("expected help URL %s, got %s", "https://docs.example.com/errors/missing-user", errorType.HelpURL)
+	}
+}
+
+func TestGenericErrorTypeIsRegistered(t *testing.T) {
+	errorType, exists := LookupErrorType(GenericErrorType)
+
+	if !exists {
+		t.Fatalf("expected %s to be registered", GenericErrorType)
+	}
+	if errorType.ErrorCode != http.StatusInternalServerError {
+		t.Errorf("expected error code %d, got %d", http.StatusInternalServerError, errorType.ErrorCode)
+	}
+	if name, _ := ErrorTypeForCode(http.StatusInternalServerError); name != InternalServerErrorType {
+		t.Errorf("expected code 500 to map to %s, got %s", InternalServerErrorType, name)
+	}
+}
+
+func TestRegisterErrorTypes(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	err := RegisterErrorTypes(map[string]ErrorType{
+		"PreconditionFailedError": {ErrorCode: http.StatusPreconditionFailed, Message: "Precondition failed"},
+		"LockedError":             {ErrorCode: http.StatusLocked, Message: "Locked"},
+	})
+
+	if err != nil {
+		t.Fatalf("expected batch to be registered, got %v", err)
+	}
+	for _, name := range []string{"PreconditionFailedError", "LockedError"} {
+		if _, exists := LookupErrorType(name); !exists {
+			t.Errorf("expected %s to be registered", name)
+		}
+	}
+}
+
+func TestRegisterErrorTypesRejectsWholeBatch(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	err := RegisterErrorTypes(map[string]ErrorType{
+		"PreconditionFailedError": {ErrorCode: http.StatusPreconditionFailed, Message: "Precondition failed"},
+		"BrokenError":             {ErrorCode: 42, Message: "Broken"},
+	})
+
+	if !errors.Is(err, ErrInvalidErrorCode) {
+		t.Errorf("expected %v, got %v", ErrInvalidErrorCode, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "BrokenError") {
+		t.Errorf("expected error to name BrokenError, got %v", err)
+	}
+	if _, exists := LookupErrorType("PreconditionFailedError"); exists {
+		t.Error("expected no type of the batch to be registered")
+	}
+}
+
+func TestSnapshotAndRestoreRegistry(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	// Arrange
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+	RegisterLocalizedMessage("PreconditionFailedError", "de", "Vorbedingung fehlgeschlagen")
+	snapshot := SnapshotRegistry()
+
+	// Act
+	UnregisterErrorType("PreconditionFailedError")
+	ResetRegistry()
+	RestoreRegistry(snapshot)
+
+	// Assert
+	errorType, exists := LookupErrorType("PreconditionFailedError")
+	if !exists || errorType.ErrorCode != http.StatusPreconditionFailed {
+		t.Errorf("expected PaymentRequiredError with code %d to be restored, got %v", http.StatusPreconditionFailed, errorType)
+	}
+	if message := NewApiError("PreconditionFailedError", "").LocalizedMessage("de"); message != "Vorbedingung fehlgeschlagen" {
+		t.Errorf("expected localized message %s, got %s", "Vorbedingung fehlgeschlagen", message)
+	}
+	if name, _ := ErrorTypeForCode(http.StatusPreconditionFailed); name != "PreconditionFailedError" {
+		t.Errorf("expected code lookup to find %s, got %s", "PreconditionFailedError", name)
+	}
+}
+
+func TestSnapshotIsIndependentOfLaterChanges(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	snapshot := SnapshotRegistry()
+
+	RegisterErrorType("PreconditionFailedError", http.StatusPreconditionFailed, "Precondition failed")
+	RestoreRegistry(snapshot)
+
+	if _, exists := LookupErrorType("PreconditionFailedError"); exists {
+		t.Error("expected type registered after the snapshot to be gone")
+	}
+}
+
+func TestAdditionalStatusErrorTypes(t *testing.T) {
+	tests := []struct {
+		errorType    string
+		expectedCode int
+	}{
+		{PaymentRequiredErrorType, http.StatusPaymentRequired},
+		{GoneErrorType, http.StatusGone},
+		{UnsupportedMediaTypeErrorType, http.StatusUnsupportedMediaType},
+		{UnavailableForLegalReasonsErrorType, http.StatusUnavailableForLegalReasons},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.errorType, func(t *testing.T) {
+			apiError := NewApiError(tt.errorType, "")
+
+			if apiError.ErrorType != tt.errorType {
+				t.Errorf("expected error type %s, got %s", tt.errorType, apiError.ErrorType)
+			}
+			if apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, apiError.ErrorCode)
+			}
+			if apiError.Message == "" {
+				t.Errorf("expected default message for %s", tt.errorType)
+			}
+			if name, _ := ErrorTypeForCode(tt.expectedCode); name != tt.errorType {
+				t.Errorf("expected code %d to map to %s, got %s", tt.expectedCode, tt.errorType, name)
+			}
+		})
+	}
+}
+
+// fakeRegistry is a Registry backed by a plain map, standing in for an external store.
+type fakeRegistry struct {
+	mu    sync.Mutex
+	types map[string]ErrorType
+	order []string
+}
+
+// newFakeRegistry returns a fakeRegistry holding types in name order.
+func newFakeRegistry(types map[string]ErrorType) *fakeRegistry {
+	fake := &fakeRegistry{types: map[string]ErrorType{}}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fake.Register(name, types[name])
+	}
+	return fake
+}
+
+func (f *fakeRegistry) Lookup(name string) (ErrorType, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	errorType, exists := f.types[name]
+	return errorType, exists
+}
+
+func (f *fakeRegistry) Register(name string, errorType ErrorType) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.types[name]; !exists {
+		f.order = append(f.order, name)
+	}
+	f.types[name] = errorType
+}
+
+func (f *fakeRegistry) Unregister(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.types, name)
+	for i, registered := range f.order {
+		if registered == name {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (f *fakeRegistry) Range(fn func(name string, errorType ErrorType) bool) {
+	f.mu.Lock()
+	names := append([]string(nil), f.order...)
+	f.mu.Unlock()
+	for _, name := range names {
+		errorType, exists := f.Lookup(name)
+		if exists && !fn(name, errorType) {
+			return
+		}
+	}
+}
+
+func TestSetRegistry(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { SetRegistry(nil) })
+	fake := newFakeRegistry(map[string]ErrorType{
+		"QuotaExceededError": {ErrorCode: http.StatusTooManyRequests, Message: "Quota exceeded", AppCode: "QUOTA_EXCEEDED"},
+	})
+
+	// Act
+	SetRegistry(fake)
+	apiError := NewApiError("QuotaExceededError", "")
+	RegisterErrorType("ArchivedError", http.StatusGone, "Archived")
+
+	// Assert
+	if apiError.ErrorCode != http.StatusTooManyRequests || apiError.Message != "Quota exceeded" || apiError.ApplicationCode != "QUOTA_EXCEEDED" {
+		t.Errorf("expected error from fake registry, got %#v", apiError)
+	}
+	if _, exists := fake.types["ArchivedError"]; !exists {
+		t.Errorf("expected ArchivedError to be registered in the fake registry")
+	}
+	if _, exists := defaultRegistry.Lookup("ArchivedError"); exists {
+		t.Errorf("expected ArchivedError to stay out of the built-in registry")
+	}
+	if _, exists := LookupErrorType(NotFoundErrorType); exists {
+		t.Errorf("expected %s to be unknown to the fake registry", NotFoundErrorType)
+	}
+
+	SetRegistry(nil)
+	if _, exists := LookupErrorType("QuotaExceededError"); exists {
+		t.Errorf("expected the built-in registry to be restored")
+	}
+}
+
+func TestRegisterErrorTypesWithCustomRegistry(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	fake := newFakeRegistry(nil)
+	SetRegistry(fake)
+
+	err := RegisterErrorTypes(map[string]ErrorType{
+		"PreconditionFailedError": {ErrorCode: http.StatusPreconditionFailed, Message: "Precondition failed"},
+		"LockedError":             {ErrorCode: http.StatusLocked, Message: "Locked"},
+	})
+
+	if err != nil {
+		t.Fatalf("expected batch to be registered, got %v", err)
+	}
+	if len(fake.types) != 2 {
+		t.Errorf("expected 2 types in the fake registry, got %v", fake.types)
+	}
+}
+
+func TestResetRegistryRestoresBuiltinRegistry(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	SetRegistry(newFakeRegistry(nil))
+
+	ResetRegistry()
+
+	if _, exists := LookupErrorType(NotFoundErrorType); !exists {
+		t.Errorf("expected %s to be registered after reset", NotFoundErrorType)
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("ArchivedError", ErrorType{ErrorCode: http.StatusGone, Message: "Archived"})
+
+	if errorType, exists := r.Lookup(NotFoundErrorType); !exists || errorType.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected built-in %s, got %v", NotFoundErrorType, errorType)
+	}
+	if _, exists := LookupErrorType("ArchivedError"); exists {
+		t.Errorf("expected a new registry to be independent of the default one")
+	}
+}
+
+func TestUnregisterErrorTypeWithCustomRegistry(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	fake := newFakeRegistry(nil)
+	SetRegistry(fake)
+	RegisterErrorType("ArchivedError", http.StatusGone, "Archived")
+
+	UnregisterErrorType("ArchivedError")
+
+	if _, exists := fake.Lookup("ArchivedError"); exists {
+		t.Errorf("expected ArchivedError to be removed from the fake registry")
+	}
+	if types := RegisteredTypes(); len(types) != 0 {
+		t.Errorf("expected no registered types, got %v", types)
+	}
+}
+
+func TestErrorTypeForCodeWithCustomRegistry(t *testing.T) {
+	// Arrange
+	t.Cleanup(ResetRegistry)
+	SetRegistry(newFakeRegistry(map[string]ErrorType{
+		"TeapotError": {ErrorCode: http.StatusTeapot, Message: "I'm a teapot"},
+	}))
+
+	// Act
+	name, exists := ErrorTypeForCode(http.StatusTeapot)
+
+	// Assert
+	if !exists || name != "TeapotError" {
+		t.Errorf("expected TeapotError for code %d, got %q", http.StatusTeapot, name)
+	}
+	if _, exists := ErrorTypeForCode(http.StatusNotFound); exists {
+		t.Errorf("expected no type for code %d in the fake registry", http.StatusNotFound)
+	}
+	if types := AllErrorTypes(); len(types) != 1 || types["TeapotError"].ErrorCode != http.StatusTeapot {
+		t.Errorf("expected only TeapotError, got %v", types)
+	}
+	if _, exists := OpenAPIResponses()["418"]; !exists {
+		t.Errorf("expected an OpenAPI response for code 418")
+	}
+}
+
+func TestSnapshotAndRestoreWithCustomRegistry(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	fake := newFakeRegistry(map[string]ErrorType{
+		"TeapotError": {ErrorCode: http.StatusTeapot, Message: "I'm a teapot"},
+	})
+	SetRegistry(fake)
+	RegisterLocalizedMessage("TeapotError", "de", "Ich bin eine Teekanne")
+	snapshot := SnapshotRegistry()
+
+	UnregisterErrorType("TeapotError")
+	RegisterErrorType("ArchivedError", http.StatusGone, "Archived")
+	RegisterLocalizedMessage("TeapotError", "de", "Teekanne")
+	RestoreRegistry(snapshot)
+
+	if types := RegisteredTypes(); len(types) != 1 || types[0] != "TeapotError" {
+		t.Errorf("expected only TeapotError after restore, got %v", types)
+	}
+	if message := NewApiError("TeapotError", "").LocalizedMessage("de"); message != "Ich bin eine Teekanne" {
+		t.Errorf("expected localized message %s, got %s", "Ich bin eine Teekanne", message)
+	}
+}
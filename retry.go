@@ -0,0 +1,26 @@
+package errors
+
+import "net/http"
+
+// retryableCodes lists the status codes that are retryable by default.
+var retryableCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// WithRetryable forces IsRetryable to return retryable regardless of the error code.
+func WithRetryable(retryable bool) ErrorOption {
+	return func(ae *ApiError) {
+		ae.retryable = &retryable
+	}
+}
+
+// IsRetryable reports whether a client may retry the request that caused the error.
+// Unless overridden with WithRetryable, 429, 503 and 504 are retryable.
+func (e *ApiError) IsRetryable() bool {
+	if e.retryable != nil {
+		return *e.retryable
+	}
+	return retryableCodes[e.ErrorCode]
+}
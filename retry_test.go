@@ -0,0 +1,37 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIsRetryableDefaults(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusBadRequest, false},
+		{http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.code), func(t *testing.T) {
+			apiError := NewApiError("", "something happened", WithCode(tt.code))
+			if apiError.IsRetryable() != tt.expected {
+				t.Errorf("expected IsRetryable %t, got %t", tt.expected, apiError.IsRetryable())
+			}
+		})
+	}
+}
+
+func TestWithRetryableOverridesDefault(t *testing.T) {
+	if !BadRequest("Try again", WithRetryable(true)).IsRetryable() {
+		t.Error("expected an overridden 400 to be retryable")
+	}
+	if TooManyRequests("Slow down", WithRetryable(false)).IsRetryable() {
+		t.Error("expected an overridden 429 not to be retryable")
+	}
+}
@@ -0,0 +1,41 @@
+package errors
+
+// apiErrorSchema is the JSON Schema of the snake_case ApiError encoding. Update it
+// together with MarshalJSON and knownJSONKeys.
+const apiErrorSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "ApiError",
+  "type": "object",
+  "required": ["error_type", "message", "error_code"],
+  "properties": {
+    "internal_error": {"type": ["string", "object", "null"]},
+    "internal_errors": {"type": "array", "items": {"type": ["string", "object"]}},
+    "error_type": {"type": "string"},
+    "message": {"type": "string"},
+    "details": {"type": "array", "items": {"type": "string"}},
+    "error_code": {
+      "oneOf": [
+        {"type": "integer"},
+        {"type": "string", "pattern": "^-?[0-9]+$"}
+      ]
+    },
+    "code": {"type": "string"},
+    "help_url": {"type": "string", "format": "uri"},
+    "metadata": {"type": "object"},
+    "trace_id": {"type": "string"},
+    "developer_message": {"type": "string"},
+    "status_text": {"type": "string"},
+    "retry_after_seconds": {"type": "integer", "minimum": 1},
+    "timestamp": {"type": "string", "format": "date-time"},
+    "stack": {"type": "array", "items": {"type": "string"}},
+    "errors": {"type": "array", "items": {"type": ["string", "object"]}}
+  },
+  "additionalProperties": true
+}`
+
+// JSONSchema returns a JSON Schema document describing the ApiError JSON encoding with
+// the default snake_case keys, for validating responses in contract tests. Unknown
+// fields are allowed, as they are kept in Extra.
+func JSONSchema() []byte {
+	return []byte(apiErrorSchema)
+}
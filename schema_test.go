@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	var schema struct {
+		Type       string                     `json:"type"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(JSONSchema(), &schema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	if schema.Type != "object" {
+		t.Errorf("expected type object, got %s", schema.Type)
+	}
+	required := append([]string(nil), schema.Required...)
+	sort.Strings(required)
+	expected := []string{"error_code", "error_type", "message"}
+	if len(required) != len(expected) {
+		t.Fatalf("expected required fields %v, got %v", expected, required)
+	}
+	for i, field := range expected {
+		if required[i] != field {
+			t.Errorf("expected required field %s, got %s", field, required[i])
+		}
+	}
+}
+
+func TestJSONSchemaCoversKnownKeys(t *testing.T) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(JSONSchema(), &schema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	for key := range knownJSONKeys {
+		if _, exists := schema.Properties[key]; !exists {
+			t.Errorf("expected schema property for %s", key)
+		}
+	}
+	for key := range schema.Properties {
+		if !knownJSONKeys[key] {
+			t.Errorf("expected %s to be a known JSON key", key)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package errors
+
+// Sentinel errors for the built-in types. errors.Is(err, ErrNotFound) reports whether
+// err has a NotFoundError ApiError in its chain, whatever its message. Sentinels carry
+// only their type and cannot be modified.
+var (
+	ErrNotFound            error = &sentinel{NotFoundErrorType}
+	ErrInternalServer      error = &sentinel{InternalServerErrorType}
+	ErrBadRequest          error = &sentinel{BadRequestErrorType}
+	ErrUnauthorized        error = &sentinel{UnauthorizedErrorType}
+	ErrForbidden           error = &sentinel{ForbiddenErrorType}
+	ErrConflict            error = &sentinel{ConflictErrorType}
+	ErrMethodNotAllowed    error = &sentinel{MethodNotAllowedErrorType}
+	ErrRequestTimeout      error = &sentinel{RequestTimeoutErrorType}
+	ErrUnprocessableEntity error = &sentinel{UnprocessableEntityErrorType}
+	ErrTooManyRequests     error = &sentinel{TooManyRequestsErrorType}
+)
+
+// sentinel is an immutable error identifying an error type.
+type sentinel struct {
+	errorType string
+}
+
+// Error returns the Error() text of an ApiError of the type with its default message.
+func (s *sentinel) Error() string {
+	return newApiError(s.errorType, "", nil).Error()
+}
+
+// Is reports whether target is an ApiError or sentinel of the same type.
+func (s *sentinel) Is(target error) bool {
+	if t, ok := target.(*sentinel); ok {
+		return t.errorType == s.errorType
+	}
+	t, ok := baseApiError(target)
+	return ok && t != nil && t.ErrorType == s.errorType
+}
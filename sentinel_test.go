@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSentinelMatchesFreshApiError(t *testing.T) {
+	tests := []struct {
+		name     string
+		sentinel error
+		err      *ApiError
+	}{
+		{"ErrNotFound", ErrNotFound, NotFound("User not found").ApiError},
+		{"ErrInternalServer", ErrInternalServer, InternalServer("boom").ApiError},
+		{"ErrBadRequest", ErrBadRequest, BadRequest("bad").ApiError},
+		{"ErrUnauthorized", ErrUnauthorized, Unauthorized("who are you").ApiError},
+		{"ErrForbidden", ErrForbidden, Forbidden("no").ApiError},
+		{"ErrConflict", ErrConflict, Conflict("taken").ApiError},
+		{"ErrMethodNotAllowed", ErrMethodNotAllowed, MethodNotAllowed("nope").ApiError},
+		{"ErrRequestTimeout", ErrRequestTimeout, RequestTimeout("slow").ApiError},
+		{"ErrUnprocessableEntity", ErrUnprocessableEntity, UnprocessableEntity("invalid").ApiError},
+		{"ErrTooManyRequests", ErrTooManyRequests, TooManyRequests("slow down").ApiError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("expected %v to match %s", tt.err, tt.name)
+			}
+			if !errors.Is(fmt.Errorf("wrapped: %w", tt.err), tt.sentinel) {
+				t.Errorf("expected wrapped %v to match %s", tt.err, tt.name)
+			}
+			if !errors.Is(tt.sentinel, tt.err) {
+				t.Errorf("expected %s to match %v", tt.name, tt.err)
+			}
+		})
+	}
+}
+
+func TestSentinelDoesNotMatchOtherTypes(t *testing.T) {
+	if errors.Is(NotFound("User not found"), ErrConflict) {
+		t.Error("expected a NotFound ApiError not to match ErrConflict")
+	}
+	if errors.Is(errors.New("Resource not found"), ErrNotFound) {
+		t.Error("expected a plain error not to match ErrNotFound")
+	}
+	if errors.Is(ErrNotFound, ErrConflict) {
+		t.Error("expected sentinels of different types not to match")
+	}
+}
+
+func TestSentinelError(t *testing.T) {
+	if ErrNotFound.Error() != "Error 404: Resource not found" {
+		t.Errorf("expected %s, got %s", "Error 404: Resource not found", ErrNotFound.Error())
+	}
+}
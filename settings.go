@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// redactInternalErrors controls whether MarshalJSON omits internal error details.
+var redactInternalErrors atomic.Bool
+
+// SetRedactInternalErrors enables or disables omitting internal_error from JSON output.
+// Enable it in production so internal details stay server-side; InternalError still
+// returns the wrapped error for logging.
+func SetRedactInternalErrors(redact bool) {
+	redactInternalErrors.Store(redact)
+}
+
+// alwaysEmitInternalError controls whether MarshalJSON writes a null internal_error instead of omitting it.
+var alwaysEmitInternalError atomic.Bool
+
+// SetAlwaysEmitInternalError makes MarshalJSON always write the internal_error key, as null
+// when there is no inner error or it is redacted, for clients that expect a fixed shape.
+// It is off by default, omitting the key.
+func SetAlwaysEmitInternalError(always bool) {
+	alwaysEmitInternalError.Store(always)
+}
+
+// includeStackInJSON controls whether MarshalJSON emits the captured stack trace.
+var includeStackInJSON atomic.Bool
+
+// SetIncludeStackInJSON enables or disables a "stack" array of file:line entries in the
+// JSON of errors created with WithStackTrace. It is off by default; keep it off in
+// production so call paths are not exposed to clients.
+func SetIncludeStackInJSON(include bool) {
+	includeStackInJSON.Store(include)
+}
+
+// includeInnerInError controls whether Error appends the inner error's message.
+var includeInnerInError atomic.Bool
+
+// SetIncludeInnerInError enables or disables appending ": <inner error>" to the message
+// returned by Error, producing for example "Error 500: db down: connection refused".
+// It is off by default.
+func SetIncludeInnerInError(include bool) {
+	includeInnerInError.Store(include)
+}
+
+// strictUnmarshal controls whether UnmarshalJSON rejects unregistered error types.
+var strictUnmarshal atomic.Bool
+
+// SetStrictUnmarshal enables or disables rejecting JSON whose error_type is not in the
+// registry, which helps clients notice drift between service versions. It is off by default.
+func SetStrictUnmarshal(strict bool) {
+	strictUnmarshal.Store(strict)
+}
+
+// codeAsString controls whether MarshalJSON writes error_code as a string.
+var codeAsString atomic.Bool
+
+// SetCodeAsString makes MarshalJSON write error_code as a string such as "404", for
+// clients that prefer not to handle it as a number. UnmarshalJSON accepts both forms
+// regardless. It is off by default.
+func SetCodeAsString(asString bool) {
+	codeAsString.Store(asString)
+}
+
+// jsonNamingStyle holds the NamingStyle used for ApiError JSON keys.
+var jsonNamingStyle atomic.Int32
+
+// SetJSONNamingStyle selects the casing of the keys emitted by MarshalJSON and expected by
+// UnmarshalJSON. Only top-level keys are affected; metadata keys are left as provided.
+func SetJSONNamingStyle(style NamingStyle) {
+	jsonNamingStyle.Store(int32(style))
+}
+
+// defaultErrorTypeName holds the name set with SetDefaultErrorType; nil means GenericError.
+var defaultErrorTypeName atomic.Pointer[string]
+
+// SetDefaultErrorType selects the registered type that NewApiError uses for unknown
+// error types, for example BadRequestErrorType to report them as 400. An empty name
+// restores GenericError, which is also used while name is not registered.
+func SetDefaultErrorType(name string) {
+	if name == "" {
+		defaultErrorTypeName.Store(nil)
+		return
+	}
+	defaultErrorTypeName.Store(&name)
+}
+
+// contentType holds the media type set with SetDefaultContentType; nil means application/json.
+var contentType atomic.Pointer[string]
+
+// SetDefaultContentType sets the Content-Type used by WriteError. With ProblemContentType
+// the body is written as problem details by ProblemJSON; any other type gets the ApiError
+// JSON. An empty string restores application/json.
+func SetDefaultContentType(mediaType string) {
+	if mediaType == "" {
+		contentType.Store(nil)
+		return
+	}
+	contentType.Store(&mediaType)
+}
+
+// defaultContentType returns the media type set with SetDefaultContentType.
+func defaultContentType() string {
+	if mediaType := contentType.Load(); mediaType != nil {
+		return *mediaType
+	}
+	return "application/json"
+}
+
+// clock holds the time source set with SetClock; nil means time.Now.
+var clock atomic.Pointer[func() time.Time]
+
+// SetClock replaces the time source used by WithNow, so tests can freeze time.
+// Passing nil restores time.Now.
+func SetClock(now func() time.Time) {
+	if now == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&now)
+}
+
+// now returns the current time from the configured clock.
+func now() time.Time {
+	if current := clock.Load(); current != nil {
+		return (*current)()
+	}
+	return time.Now()
+}
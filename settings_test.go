@@ -0,0 +1,364 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetRedactInternalErrors(t *testing.T) {
+	t.Cleanup(func() { SetRedactInternalErrors(false) })
+	internalErr := errors.New("dial tcp 10.0.0.1:5432: connection refused")
+	apiError := NewApiError(InternalServerErrorType, "Internal server error", WithInternalError(internalErr))
+
+	tests := []struct {
+		name         string
+		redact       bool
+		expectedJSON string
+	}{
+		{"disabled", false, `{"internal_error":"dial tcp 10.0.0.1:5432: connection refused","error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`},
+		{"enabled", true, `{"error_type":"InternalServerError","message":"Internal server error","error_code":500,"status_text":"Internal Server Error"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRedactInternalErrors(tt.redact)
+
+			jsonData, err := json.Marshal(apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+			if apiError.InternalError() != internalErr {
+				t.Errorf("expected internal error %v, got %v", internalErr, apiError.InternalError())
+			}
+		})
+	}
+}
+
+func TestSetJSONNamingStyle(t *testing.T) {
+	t.Cleanup(func() { SetJSONNamingStyle(SnakeCase) })
+	apiError := NewApiError(NotFoundErrorType, "User not found",
+		WithInternalError(BadRequest("Invalid id")),
+		WithMetadata("user_id", 42),
+		WithTraceID("4bf92f3577b34da6"),
+	)
+
+	tests := []struct {
+		name         string
+		style        NamingStyle
+		expectedJSON string
+	}{
+		{"snake case", SnakeCase, `{"internal_error":{"error_type":"BadRequestError","message":"Invalid id","error_code":400,"status_text":"Bad Request"},"error_type":"NotFoundError","message":"User not found","error_code":404,"metadata":{"user_id":42},"trace_id":"4bf92f3577b34da6","status_text":"Not Found"}`},
+		{"camel case", CamelCase, `{"internalError":{"errorType":"BadRequestError","message":"Invalid id","errorCode":400,"statusText":"Bad Request"},"errorType":"NotFoundError","message":"User not found","errorCode":404,"metadata":{"user_id":42},"traceId":"4bf92f3577b34da6","statusText":"Not Found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetJSONNamingStyle(tt.style)
+
+			jsonData, err := json.Marshal(apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+
+			var decoded ApiError
+			if err := json.Unmarshal(jsonData, &decoded); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if decoded.ErrorType != NotFoundErrorType || decoded.ErrorCode != 404 || decoded.TraceID != "4bf92f3577b34da6" {
+				t.Errorf("expected round-tripped error, got %#v", &decoded)
+			}
+			if !IsBadRequest(decoded.InnerError) {
+				t.Errorf("expected nested BadRequest ApiError, got %v", decoded.InnerError)
+			}
+		})
+	}
+}
+
+func TestSetDefaultErrorType(t *testing.T) {
+	t.Cleanup(func() { SetDefaultErrorType("") })
+
+	tests := []struct {
+		name         string
+		defaultType  string
+		expectedType string
+		expectedCode int
+	}{
+		{"generic by default", "", GenericErrorType, http.StatusInternalServerError},
+		{"registered type", BadRequestErrorType, BadRequestErrorType, http.StatusBadRequest},
+		{"unregistered type falls back to generic", "MadeUpError", GenericErrorType, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaultErrorType(tt.defaultType)
+
+			apiError := NewApiError("UnknownError", "")
+
+			if apiError.ErrorType != tt.expectedType {
+				t.Errorf("expected error type %s, got %s", tt.expectedType, apiError.ErrorType)
+			}
+			if apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, apiError.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestSetIncludeStackInJSON(t *testing.T) {
+	t.Cleanup(func() { SetIncludeStackInJSON(false) })
+	apiError := InternalServer("Internal server error", WithStackTrace())
+
+	tests := []struct {
+		name        string
+		include     bool
+		expectStack bool
+	}{
+		{"disabled", false, false},
+		{"enabled", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetIncludeStackInJSON(tt.include)
+
+			jsonData, err := json.Marshal(apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+			var decoded struct {
+				Stack []string `json:"stack"`
+			}
+			if err := json.Unmarshal(jsonData, &decoded); err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+
+			if tt.expectStack && (len(decoded.Stack) == 0 || !strings.Contains(decoded.Stack[0], "settings_test.go:")) {
+				t.Errorf("expected stack starting in settings_test.go, got %v", decoded.Stack)
+			}
+			if !tt.expectStack && decoded.Stack != nil {
+				t.Errorf("expected no stack, got %v", decoded.Stack)
+			}
+		})
+	}
+}
+
+func TestSetIncludeStackInJSONWithoutCapturedStack(t *testing.T) {
+	t.Cleanup(func() { SetIncludeStackInJSON(false) })
+	SetIncludeStackInJSON(true)
+
+	jsonData, err := json.Marshal(NotFound("User not found"))
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	if strings.Contains(string(jsonData), "stack") {
+		t.Errorf("expected no stack, got %s", jsonData)
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	t.Cleanup(func() { SetClock(nil) })
+	frozen := time.Date(2024, time.March, 14, 9, 26, 53, 0, time.UTC)
+	SetClock(func() time.Time { return frozen })
+
+	apiError := NotFound("User not found", WithNow())
+
+	if !apiError.Timestamp.Equal(frozen) {
+		t.Errorf("expected timestamp %s, got %s", frozen, apiError.Timestamp)
+	}
+}
+
+func TestSetIncludeInnerInError(t *testing.T) {
+	t.Cleanup(func() { SetIncludeInnerInError(false) })
+
+	tests := []struct {
+		name     string
+		include  bool
+		apiError *ApiError
+		expected string
+	}{
+		{"disabled with inner error", false, InternalServer("db down", WithInternalError(errors.New("connection refused"))).ApiError, "Error 500: db down"},
+		{"enabled with inner error", true, InternalServer("db down", WithInternalError(errors.New("connection refused"))).ApiError, "Error 500: db down: connection refused"},
+		{"enabled with nested ApiError", true, InternalServer("db down", WithInternalError(NotFound("no user", WithInternalError(errors.New("no rows"))))).ApiError, "Error 500: db down: Error 404: no user: no rows"},
+		{"enabled without inner error", true, InternalServer("db down").ApiError, "Error 500: db down"},
+		{"disabled without inner error", false, InternalServer("db down").ApiError, "Error 500: db down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetIncludeInnerInError(tt.include)
+
+			if got := tt.apiError.Error(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetStrictUnmarshal(t *testing.T) {
+	t.Cleanup(func() { SetStrictUnmarshal(false) })
+
+	tests := []struct {
+		name      string
+		strict    bool
+		input     string
+		expectErr bool
+	}{
+		{"lenient accepts unknown type", false, `{"error_type":"MadeUpError","message":"Oops","error_code":500}`, false},
+		{"strict rejects unknown type", true, `{"error_type":"MadeUpError","message":"Oops","error_code":500}`, true},
+		{"strict rejects unknown nested type", true, `{"internal_error":{"error_type":"MadeUpError"},"error_type":"NotFoundError"}`, true},
+		{"strict accepts registered type", true, `{"error_type":"NotFoundError","message":"User not found","error_code":404}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetStrictUnmarshal(tt.strict)
+
+			var decoded ApiError
+			err := json.Unmarshal([]byte(tt.input), &decoded)
+
+			if tt.expectErr && !errors.Is(err, ErrUnknownErrorType) {
+				t.Errorf("expected %v, got %v", ErrUnknownErrorType, err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetAlwaysEmitInternalError(t *testing.T) {
+	t.Cleanup(func() { SetAlwaysEmitInternalError(false) })
+
+	tests := []struct {
+		name         string
+		always       bool
+		apiError     *ApiError
+		expectedJSON string
+	}{
+		{"omitted without inner error", false, NotFound("User not found").ApiError, `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"null without inner error", true, NotFound("User not found").ApiError, `{"internal_error":null,"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"present with inner error", false, NotFound("User not found", WithInternalError(errors.New("no rows"))).ApiError, `{"internal_error":"no rows","error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"unchanged with inner error", true, NotFound("User not found", WithInternalError(errors.New("no rows"))).ApiError, `{"internal_error":"no rows","error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetAlwaysEmitInternalError(tt.always)
+
+			jsonData, err := json.Marshal(tt.apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+		})
+	}
+}
+
+func TestDeveloperMessageFollowsRedaction(t *testing.T) {
+	t.Cleanup(func() { SetRedactInternalErrors(false) })
+	apiError := NotFound("User not found", WithDeveloperMessage("users table has no row for id 42"))
+
+	tests := []struct {
+		name         string
+		redact       bool
+		expectedJSON string
+	}{
+		{"included without redaction", false, `{"error_type":"NotFoundError","message":"User not found","error_code":404,"developer_message":"users table has no row for id 42","status_text":"Not Found"}`},
+		{"excluded with redaction", true, `{"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRedactInternalErrors(tt.redact)
+
+			jsonData, err := json.Marshal(apiError)
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+		})
+	}
+}
+
+func TestSetCodeAsString(t *testing.T) {
+	t.Cleanup(func() { SetCodeAsString(false) })
+
+	tests := []struct {
+		name         string
+		asString     bool
+		expectedJSON string
+	}{
+		{"number by default", false, `{"internal_error":{"error_type":"BadRequestError","message":"Invalid id","error_code":400,"status_text":"Bad Request"},"error_type":"NotFoundError","message":"User not found","error_code":404,"status_text":"Not Found"}`},
+		{"string when enabled", true, `{"internal_error":{"error_type":"BadRequestError","message":"Invalid id","error_code":"400","status_text":"Bad Request"},"error_type":"NotFoundError","message":"User not found","error_code":"404","status_text":"Not Found"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetCodeAsString(tt.asString)
+
+			jsonData, err := json.Marshal(NotFound("User not found", WithInternalError(BadRequest("Invalid id"))))
+			if err != nil {
+				t.Fatalf("failed to marshal ApiError: %v", err)
+			}
+
+			if string(jsonData) != tt.expectedJSON {
+				t.Errorf("expected %s, got %s", tt.expectedJSON, string(jsonData))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONAcceptsStringCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedCode int
+		expectErr    bool
+	}{
+		{"number", `{"error_type":"NotFoundError","message":"User not found","error_code":404}`, http.StatusNotFound, false},
+		{"string", `{"error_type":"NotFoundError","message":"User not found","error_code":"410"}`, http.StatusGone, false},
+		{"nested string", `{"internal_error":{"error_type":"BadRequestError","error_code":"400"},"error_type":"NotFoundError","error_code":"404"}`, http.StatusNotFound, false},
+		{"invalid string", `{"error_type":"NotFoundError","error_code":"four"}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded ApiError
+			err := json.Unmarshal([]byte(tt.input), &decoded)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected an error, got %#v", &decoded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to unmarshal JSON: %v", err)
+			}
+			if decoded.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, decoded.ErrorCode)
+			}
+			if decoded.Extra != nil {
+				t.Errorf("expected no extra fields, got %v", decoded.Extra)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package errors
+
+// Severity classifies how urgently an error needs attention.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota + 1
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// String returns the lower-case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// WithSeverity overrides the severity derived from the error code.
+func WithSeverity(severity Severity) ErrorOption {
+	return func(ae *ApiError) {
+		ae.severity = severity
+	}
+}
+
+// Severity returns the severity set by WithSeverity, otherwise it is derived from
+// the error code: 5xx is SeverityError, 4xx is SeverityWarning and anything else is SeverityInfo.
+func (e *ApiError) Severity() Severity {
+	switch {
+	case e.severity != 0:
+		return e.severity
+	case e.ErrorCode >= 500 && e.ErrorCode < 600:
+		return SeverityError
+	case e.ErrorCode >= 400 && e.ErrorCode < 500:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
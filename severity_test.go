@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSeverityDerivedFromCode(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected Severity
+	}{
+		{http.StatusNotFound, SeverityWarning},
+		{http.StatusTooManyRequests, SeverityWarning},
+		{http.StatusInternalServerError, SeverityError},
+		{http.StatusServiceUnavailable, SeverityError},
+		{http.StatusMovedPermanently, SeverityInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.code), func(t *testing.T) {
+			apiError := NewApiError("", "something happened", WithCode(tt.code))
+			if apiError.Severity() != tt.expected {
+				t.Errorf("expected severity %s, got %s", tt.expected, apiError.Severity())
+			}
+		})
+	}
+}
+
+func TestWithSeverityOverridesDerivedSeverity(t *testing.T) {
+	apiError := InternalServer("Database unreachable", WithSeverity(SeverityCritical))
+
+	if apiError.Severity() != SeverityCritical {
+		t.Errorf("expected severity %s, got %s", SeverityCritical, apiError.Severity())
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	tests := map[Severity]string{
+		SeverityInfo:     "info",
+		SeverityWarning:  "warning",
+		SeverityError:    "error",
+		SeverityCritical: "critical",
+		Severity(0):      "unknown",
+	}
+
+	for severity, expected := range tests {
+		if severity.String() != expected {
+			t.Errorf("expected %s, got %s", expected, severity.String())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// LogValue implements slog.LogValuer so the error is logged as a group of
+// type, code, message and, when present, the inner error.
+func (e *ApiError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("type", e.ErrorType),
+		slog.Int("code", e.ErrorCode),
+		slog.String("message", e.message()),
+	}
+	if e.InnerError != nil {
+		attrs = append(attrs, slog.String("inner_error", e.InnerError.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logLevels holds the per-code overrides set with SetLogLevelForCode.
+var logLevels = struct {
+	mu     sync.RWMutex
+	byCode map[int]slog.Level
+}{byCode: make(map[int]slog.Level)}
+
+// SetLogLevelForCode makes LogLevel return level for every error with code. It is safe for concurrent use.
+func SetLogLevelForCode(code int, level slog.Level) {
+	logLevels.mu.Lock()
+	defer logLevels.mu.Unlock()
+	logLevels.byCode[code] = level
+}
+
+// ResetLogLevels removes every override set with SetLogLevelForCode. It is intended for test teardown.
+func ResetLogLevels() {
+	logLevels.mu.Lock()
+	defer logLevels.mu.Unlock()
+	clear(logLevels.byCode)
+}
+
+// LogLevel returns the level to log the error at. An override set with SetLogLevelForCode
+// wins; otherwise 404 is logged at Info and other codes follow Severity, so 5xx errors are
+// logged at Error and remaining 4xx errors, such as 401 and 403, at Warn.
+func (e *ApiError) LogLevel() slog.Level {
+	logLevels.mu.RLock()
+	level, exists := logLevels.byCode[e.ErrorCode]
+	logLevels.mu.RUnlock()
+	if exists {
+		return level
+	}
+	if e.severity == 0 && e.ErrorCode == http.StatusNotFound {
+		return slog.LevelInfo
+	}
+	switch e.Severity() {
+	case SeverityError, SeverityCritical:
+		return slog.LevelError
+	case SeverityWarning:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
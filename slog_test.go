@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// captureHandler records the attributes of every handled log record.
+type captureHandler struct {
+	attrs []slog.Attr
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *captureHandler) Handle(_ context.Context, record slog.Record) error {
+	record.Attrs(func(attr slog.Attr) bool {
+		attr.Value = attr.Value.Resolve()
+		h.attrs = append(h.attrs, attr)
+		return true
+	})
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *captureHandler) WithGroup(string) slog.Handler { return h }
+
+func TestLogValue(t *testing.T) {
+	// Arrange
+	handler := &captureHandler{}
+	logger := slog.New(handler)
+	apiError := NotFound("User not found", WithInternalError(errors.New("no rows")))
+
+	// Act
+	logger.Error("request failed", "err", apiError)
+
+	// Assert
+	if len(handler.attrs) != 1 {
+		t.Fatalf("expected 1 attribute, got %d", len(handler.attrs))
+	}
+	attr := handler.attrs[0]
+	if attr.Key != "err" || attr.Value.Kind() != slog.KindGroup {
+		t.Fatalf("expected err group attribute, got %s of kind %s", attr.Key, attr.Value.Kind())
+	}
+
+	expected := map[string]string{
+		"type":        NotFoundErrorType,
+		"code":        "404",
+		"message":     "User not found",
+		"inner_error": "no rows",
+	}
+	group := attr.Value.Group()
+	if len(group) != len(expected) {
+		t.Fatalf("expected %d group attributes, got %d", len(expected), len(group))
+	}
+	for _, groupAttr := range group {
+		if groupAttr.Value.String() != expected[groupAttr.Key] {
+			t.Errorf("expected %s to be %s, got %s", groupAttr.Key, expected[groupAttr.Key], groupAttr.Value.String())
+		}
+	}
+}
+
+func TestLogValueWithoutInnerError(t *testing.T) {
+	group := NotFound("User not found").LogValue().Group()
+
+	for _, attr := range group {
+		if attr.Key == "inner_error" {
+			t.Error("expected no inner_error attribute without an inner error")
+		}
+	}
+}
+
+func TestLogLevelDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected slog.Level
+	}{
+		{"server error", InternalServer("boom").ApiError, slog.LevelError},
+		{"unauthorized", Unauthorized("Who are you").ApiError, slog.LevelWarn},
+		{"forbidden", Forbidden("Not yours").ApiError, slog.LevelWarn},
+		{"not found", NotFound("User not found").ApiError, slog.LevelInfo},
+		{"explicit severity", NotFound("User not found", WithSeverity(SeverityCritical)).ApiError, slog.LevelError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if level := tt.apiError.LogLevel(); level != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, level)
+			}
+		})
+	}
+}
+
+func TestSetLogLevelForCode(t *testing.T) {
+	t.Cleanup(ResetLogLevels)
+
+	SetLogLevelForCode(http.StatusNotFound, slog.LevelDebug)
+
+	if level := NotFound("User not found").LogLevel(); level != slog.LevelDebug {
+		t.Errorf("expected %s, got %s", slog.LevelDebug, level)
+	}
+	if level := Conflict("Taken").LogLevel(); level != slog.LevelWarn {
+		t.Errorf("expected %s, got %s", slog.LevelWarn, level)
+	}
+}
@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth limits how many frames WithStackTrace records.
+const maxStackDepth = 32
+
+// stackSkip skips runtime.Callers, the WithStackTrace option, newApiError and
+// the exported constructor, so the first recorded frame is the constructor's caller.
+const stackSkip = 4
+
+// WithStackTrace records the call stack at the point the error is created.
+// Capturing is opt-in because resolving callers is comparatively expensive.
+func WithStackTrace() ErrorOption {
+	return func(ae *ApiError) {
+		pcs := make([]uintptr, maxStackDepth)
+		n := runtime.Callers(stackSkip, pcs)
+		ae.stack = pcs[:n]
+	}
+}
+
+// StackTrace returns the program counters captured by WithStackTrace, or nil if none were captured.
+func (e *ApiError) StackTrace() []uintptr {
+	return e.stack
+}
+
+// FormatStack resolves the captured stack to one file:line entry per line.
+func (e *ApiError) FormatStack() string {
+	return strings.Join(e.stackFrames(), "\n")
+}
+
+// stackFrames resolves the captured program counters to file:line strings.
+func (e *ApiError) stackFrames() []string {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	var lines []string
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		lines = append(lines, fmt.Sprintf("%s:%d", frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return lines
+}
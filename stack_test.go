@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWithStackTraceIsOptIn(t *testing.T) {
+	apiError := NewApiError(NotFoundErrorType, "User not found")
+
+	if apiError.StackTrace() != nil {
+		t.Errorf("expected no stack trace, got %v", apiError.StackTrace())
+	}
+	if apiError.FormatStack() != "" {
+		t.Errorf("expected empty formatted stack, got %s", apiError.FormatStack())
+	}
+}
+
+func TestWithStackTraceTopFrameIsCaller(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() *ApiError
+		function string
+	}{
+		{"NewApiError", func() *ApiError {
+			return NewApiError(NotFoundErrorType, "User not found", WithStackTrace())
+		}, "TestWithStackTraceTopFrameIsCaller.func1"},
+		{"NotFound", func() *ApiError {
+			return NotFound("User not found", WithStackTrace()).ApiError
+		}, "TestWithStackTraceTopFrameIsCaller.func2"},
+		{"New", func() *ApiError {
+			return New(NotFoundErrorType, "User not found", WithStackTrace()).(*ApiError)
+		}, "TestWithStackTraceTopFrameIsCaller.func3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiError := tt.build()
+
+			stack := apiError.StackTrace()
+			if len(stack) == 0 {
+				t.Fatal("expected a captured stack trace")
+			}
+			frame, _ := runtime.CallersFrames(stack).Next()
+			if !strings.HasSuffix(frame.Function, tt.function) {
+				t.Errorf("expected top frame in %s, got %s", tt.function, frame.Function)
+			}
+			if !strings.HasPrefix(apiError.FormatStack(), frame.File) {
+				t.Errorf("expected formatted stack to start with %s, got %s", frame.File, apiError.FormatStack())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+)
+
+// stdlibErrorTypes maps standard library errors to the error types FromError uses for them.
+var stdlibErrorTypes = []struct {
+	target    error
+	errorType string
+}{
+	{sql.ErrNoRows, NotFoundErrorType},
+	{context.DeadlineExceeded, RequestTimeoutErrorType},
+	{context.Canceled, ClientClosedRequestErrorType},
+	{os.ErrPermission, ForbiddenErrorType},
+}
+
+// FromError translates err into an ApiError at an API boundary. An ApiError already in
+// err's chain is returned as is, or with its field messages for a ValidationError; well-known standard library errors such as sql.ErrNoRows
+// or context.DeadlineExceeded map to their matching type, and anything else becomes an
+// InternalServerError. The original error is kept as the inner error. A nil err returns nil.
+func FromError(err error) *ApiError {
+	if err == nil {
+		return nil
+	}
+	if apiError := apiErrorInChain(err); apiError != nil {
+		return apiError
+	}
+	for _, mapping := range stdlibErrorTypes {
+		if errors.Is(err, mapping.target) {
+			return newApiError(mapping.errorType, "", []ErrorOption{WithInternalError(err)})
+		}
+	}
+	return newApiError(InternalServerErrorType, "", []ErrorOption{WithInternalError(err)})
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedType string
+		expectedCode int
+	}{
+		{"sql.ErrNoRows", fmt.Errorf("find user: %w", sql.ErrNoRows), NotFoundErrorType, http.StatusNotFound},
+		{"context.DeadlineExceeded", context.DeadlineExceeded, RequestTimeoutErrorType, http.StatusRequestTimeout},
+		{"context.Canceled", context.Canceled, ClientClosedRequestErrorType, StatusClientClosedRequest},
+		{"os.ErrPermission", &fs.PathError{Op: "open", Path: "/etc/shadow", Err: os.ErrPermission}, ForbiddenErrorType, http.StatusForbidden},
+		{"default", errors.New("connection refused"), InternalServerErrorType, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiError := FromError(tt.err)
+
+			if apiError.ErrorType != tt.expectedType {
+				t.Errorf("expected error type %s, got %s", tt.expectedType, apiError.ErrorType)
+			}
+			if apiError.ErrorCode != tt.expectedCode {
+				t.Errorf("expected error code %d, got %d", tt.expectedCode, apiError.ErrorCode)
+			}
+			if !errors.Is(apiError, tt.err) {
+				t.Errorf("expected the original error %v to be wrapped", tt.err)
+			}
+		})
+	}
+}
+
+func TestFromErrorKeepsExistingApiError(t *testing.T) {
+	apiError := Conflict("Email already taken")
+
+	if FromError(fmt.Errorf("create user: %w", apiError)) != apiError.ApiError {
+		t.Error("expected FromError to return the existing ApiError")
+	}
+	if FromError(nil) != nil {
+		t.Error("expected FromError to return nil for nil")
+	}
+}
+
+func TestFromErrorKeepsValidationFields(t *testing.T) {
+	validationError := NewValidationError().AddFieldError("email", "is required")
+
+	apiError := FromError(fmt.Errorf("create user: %w", validationError))
+
+	if string(apiError.Extra["fields"]) != `{"email":["is required"]}` {
+		t.Errorf("expected the field messages to be kept, got %s", apiError.Extra["fields"])
+	}
+}
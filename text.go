@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// make sure ApiError implements the encoding text interfaces in compile time
+var (
+	_ encoding.TextMarshaler   = (*ApiError)(nil)
+	_ encoding.TextUnmarshaler = (*ApiError)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler with the compact form "type/code: message",
+// for example "NotFoundError/404: User not found". A nil ApiError marshals to empty text.
+func (e *ApiError) MarshalText() ([]byte, error) {
+	if e == nil {
+		return nil, nil
+	}
+	return []byte(e.ErrorType + "/" + strconv.Itoa(e.ErrorCode) + ": " + e.message()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the form produced by MarshalText.
+func (e *ApiError) UnmarshalText(text []byte) error {
+	head, message, found := strings.Cut(string(text), ": ")
+	if !found {
+		return fmt.Errorf("errors: invalid ApiError text %q", text)
+	}
+	slash := strings.LastIndex(head, "/")
+	if slash <= 0 {
+		return fmt.Errorf("errors: invalid ApiError text %q", text)
+	}
+	code, err := strconv.Atoi(head[slash+1:])
+	if err != nil {
+		return fmt.Errorf("errors: invalid code in ApiError text %q: %w", text, err)
+	}
+	e.ErrorType = head[:slash]
+	e.ErrorCode = code
+	e.Message = message
+	return nil
+}
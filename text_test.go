@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		expected string
+	}{
+		{"simple", NotFound("User not found").ApiError, "NotFoundError/404: User not found"},
+		{"message with separators", BadRequest("Invalid field: a/b: c").ApiError, "BadRequestError/400: Invalid field: a/b: c"},
+		{"empty message", &ApiError{ErrorType: ConflictErrorType, ErrorCode: http.StatusConflict}, "ConflictError/409: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			text, err := tt.apiError.MarshalText()
+			if err != nil {
+				t.Fatalf("failed to marshal text: %v", err)
+			}
+			var decoded ApiError
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("failed to unmarshal text: %v", err)
+			}
+
+			// Assert
+			if string(text) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, text)
+			}
+			if !Equal(&decoded, tt.apiError) {
+				t.Errorf("expected %v, got %v", tt.apiError, &decoded)
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextRejectsInvalidInput(t *testing.T) {
+	tests := []string{"", "NotFoundError 404 User not found", "NotFoundError: missing code", "/404: no type", "NotFoundError/abc: bad code"}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			var decoded ApiError
+			if err := decoded.UnmarshalText([]byte(input)); err == nil {
+				t.Errorf("expected an error for %q", input)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package errors
+
+// Typed errors let callers branch on a Go type instead of the ErrorType string:
+//
+//	var notFound *NotFoundError
+//	if errors.As(err, &notFound) { ... }
+//
+// The convenience constructors return them, and errors.As also fills them from any
+// ApiError of the matching type in the chain. Each typed error unwraps to its ApiError.
+type (
+	// NotFoundError is an ApiError of type NotFoundError.
+	NotFoundError struct{ *ApiError }
+	// InternalServerError is an ApiError of type InternalServerError.
+	InternalServerError struct{ *ApiError }
+	// BadRequestError is an ApiError of type BadRequestError.
+	BadRequestError struct{ *ApiError }
+	// UnauthorizedError is an ApiError of type UnauthorizedError.
+	UnauthorizedError struct{ *ApiError }
+	// ForbiddenError is an ApiError of type ForbiddenError.
+	ForbiddenError struct{ *ApiError }
+	// ConflictError is an ApiError of type ConflictError.
+	ConflictError struct{ *ApiError }
+	// MethodNotAllowedError is an ApiError of type MethodNotAllowedError.
+	MethodNotAllowedError struct{ *ApiError }
+	// RequestTimeoutError is an ApiError of type RequestTimeoutError.
+	RequestTimeoutError struct{ *ApiError }
+	// UnprocessableEntityError is an ApiError of type UnprocessableEntityError.
+	UnprocessableEntityError struct{ *ApiError }
+	// TooManyRequestsError is an ApiError of type TooManyRequestsError.
+	TooManyRequestsError struct{ *ApiError }
+)
+
+func (e *NotFoundError) Unwrap() error            { return e.ApiError }
+func (e *InternalServerError) Unwrap() error      { return e.ApiError }
+func (e *BadRequestError) Unwrap() error          { return e.ApiError }
+func (e *UnauthorizedError) Unwrap() error        { return e.ApiError }
+func (e *ForbiddenError) Unwrap() error           { return e.ApiError }
+func (e *ConflictError) Unwrap() error            { return e.ApiError }
+func (e *MethodNotAllowedError) Unwrap() error    { return e.ApiError }
+func (e *RequestTimeoutError) Unwrap() error      { return e.ApiError }
+func (e *UnprocessableEntityError) Unwrap() error { return e.ApiError }
+func (e *TooManyRequestsError) Unwrap() error     { return e.ApiError }
+
+// As lets errors.As extract a typed error, such as *NotFoundError, from an ApiError of the matching type.
+func (e *ApiError) As(target any) bool {
+	switch t := target.(type) {
+	case **NotFoundError:
+		return asTyped(e, NotFoundErrorType, t)
+	case **InternalServerError:
+		return asTyped(e, InternalServerErrorType, t)
+	case **BadRequestError:
+		return asTyped(e, BadRequestErrorType, t)
+	case **UnauthorizedError:
+		return asTyped(e, UnauthorizedErrorType, t)
+	case **ForbiddenError:
+		return asTyped(e, ForbiddenErrorType, t)
+	case **ConflictError:
+		return asTyped(e, ConflictErrorType, t)
+	case **MethodNotAllowedError:
+		return asTyped(e, MethodNotAllowedErrorType, t)
+	case **RequestTimeoutError:
+		return asTyped(e, RequestTimeoutErrorType, t)
+	case **UnprocessableEntityError:
+		return asTyped(e, UnprocessableEntityErrorType, t)
+	case **TooManyRequestsError:
+		return asTyped(e, TooManyRequestsErrorType, t)
+	default:
+		return false
+	}
+}
+
+// asTyped sets target to a typed error wrapping e when e has the given type.
+func asTyped[T ~struct{ *ApiError }](e *ApiError, errorType string, target **T) bool {
+	if e == nil || e.ErrorType != errorType {
+		return false
+	}
+	typed := T{e}
+	*target = &typed
+	return true
+}
+
+// isTypedErrorName reports whether name is the Go type name of a typed error.
+func isTypedErrorName(name string) bool {
+	switch name {
+	case NotFoundErrorType, InternalServerErrorType, BadRequestErrorType, UnauthorizedErrorType,
+		ForbiddenErrorType, ConflictErrorType, MethodNotAllowedErrorType, RequestTimeoutErrorType,
+		UnprocessableEntityErrorType, TooManyRequestsErrorType:
+		return true
+	default:
+		return false
+	}
+}
+
+// base returns the ApiError itself. Typed errors promote it, which lets package code
+// treat them as the ApiError they embed.
+func (e *ApiError) base() *ApiError {
+	return e
+}
+
+// baseApiError returns the ApiError behind err when err is an *ApiError or a typed error.
+func baseApiError(err error) (*ApiError, bool) {
+	b, ok := err.(interface{ base() *ApiError })
+	if !ok {
+		return nil, false
+	}
+	return b.base(), true
+}
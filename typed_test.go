@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorsAsTypedError(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError *ApiError
+		as       func(error) (error, bool)
+	}{
+		{"not found", NotFound("User not found").ApiError, func(err error) (error, bool) {
+			var typed *NotFoundError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"internal server", InternalServer("boom").ApiError, func(err error) (error, bool) {
+			var typed *InternalServerError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"bad request", BadRequest("Invalid id").ApiError, func(err error) (error, bool) {
+			var typed *BadRequestError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"unauthorized", Unauthorized("Who are you").ApiError, func(err error) (error, bool) {
+			var typed *UnauthorizedError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"forbidden", Forbidden("Not yours").ApiError, func(err error) (error, bool) {
+			var typed *ForbiddenError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"conflict", Conflict("Taken").ApiError, func(err error) (error, bool) {
+			var typed *ConflictError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"method not allowed", MethodNotAllowed("No").ApiError, func(err error) (error, bool) {
+			var typed *MethodNotAllowedError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"request timeout", RequestTimeout("Too slow").ApiError, func(err error) (error, bool) {
+			var typed *RequestTimeoutError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"unprocessable entity", UnprocessableEntity("Invalid email").ApiError, func(err error) (error, bool) {
+			var typed *UnprocessableEntityError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+		{"too many requests", TooManyRequests("Slow down").ApiError, func(err error) (error, bool) {
+			var typed *TooManyRequestsError
+			ok := errors.As(err, &typed)
+			return typed, ok
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			extracted, ok := tt.as(fmt.Errorf("handler: %w", tt.apiError))
+
+			// Assert
+			if !ok {
+				t.Fatalf("expected errors.As to extract the typed error from %v", tt.apiError)
+			}
+			if errors.Unwrap(extracted) != tt.apiError {
+				t.Errorf("expected typed error to wrap %v, got %v", tt.apiError, extracted)
+			}
+		})
+	}
+}
+
+func TestErrorsAsTypedErrorRejectsOtherTypes(t *testing.T) {
+	var notFound *NotFoundError
+
+	if errors.As(Conflict("Taken"), &notFound) {
+		t.Errorf("expected a ConflictError not to be a NotFoundError, got %v", notFound)
+	}
+}
+
+func TestTypedErrorBehavesLikeApiError(t *testing.T) {
+	var notFound *NotFoundError
+	if !errors.As(NotFound("User not found"), &notFound) {
+		t.Fatal("expected errors.As to extract a NotFoundError")
+	}
+
+	if notFound.Message != "User not found" || notFound.Code() != 404 {
+		t.Errorf("expected the embedded ApiError fields, got %v", notFound)
+	}
+	if !errors.Is(notFound, ErrNotFound) {
+		t.Error("expected the typed error to match ErrNotFound")
+	}
+}
+
+func TestConstructorsReturnTypedErrors(t *testing.T) {
+	// Arrange
+	var err error = NotFound("User not found")
+
+	// Act
+	var notFound *NotFoundError
+	ok := errors.As(fmt.Errorf("handler: %w", err), &notFound)
+
+	// Assert
+	if !ok || notFound != err {
+		t.Errorf("expected errors.As to return the constructed NotFoundError, got %v", notFound)
+	}
+	if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("expected NotFound to return a *NotFoundError, got %T", err)
+	}
+}
+
+func TestTypedErrorAsInnerError(t *testing.T) {
+	// Arrange
+	t.Cleanup(func() { SetIncludeInnerInError(false) })
+	SetIncludeInnerInError(true)
+	inner := NotFound("User not found", WithInternalError(errors.New("no rows")))
+	outer := InternalServer("boom", WithInternalError(inner))
+
+	// Assert: nested typed errors are encoded and compared like ApiErrors
+	expected := "Error 500: boom: Error 404: User not found: no rows"
+	if outer.Error() != expected {
+		t.Errorf("expected %s, got %s", expected, outer.Error())
+	}
+	if !errors.Is(NotFound("Order not found"), inner) {
+		t.Error("expected typed errors of the same type to match")
+	}
+	if wrapped := Wrap(inner, errors.New("cache miss")); wrapped.ErrorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, wrapped.ErrorType)
+	}
+}
@@ -0,0 +1,74 @@
+package errors
+
+import "encoding/json"
+
+// ValidationError is an UnprocessableEntityError carrying per-field messages.
+type ValidationError struct {
+	ApiError
+	Fields map[string][]string `json:"fields,omitempty"`
+}
+
+// NewValidationError creates an empty ValidationError; use AddFieldError to record failures.
+func NewValidationError(options ...ErrorOption) *ValidationError {
+	return &ValidationError{ApiError: *newApiError(UnprocessableEntityErrorType, "", options)}
+}
+
+// AddFieldError appends a message for field and returns the ValidationError for chaining.
+func (v *ValidationError) AddFieldError(field, msg string) *ValidationError {
+	if v.Fields == nil {
+		v.Fields = make(map[string][]string)
+	}
+	v.Fields[field] = append(v.Fields[field], msg)
+	return v
+}
+
+// Unwrap returns the embedded ApiError so errors.As and the Is* predicates see it.
+func (v *ValidationError) Unwrap() error {
+	return &v.ApiError
+}
+
+// withFields returns the embedded ApiError, as a copy carrying the field messages in
+// Extra when there are any, so they are encoded where only the ApiError is written.
+func (v *ValidationError) withFields() *ApiError {
+	if len(v.Fields) == 0 {
+		return &v.ApiError
+	}
+	fields, err := json.Marshal(v.Fields)
+	if err != nil {
+		return &v.ApiError
+	}
+	apiError := v.ApiError.Clone()
+	if apiError.Extra == nil {
+		apiError.Extra = make(map[string]json.RawMessage, 1)
+	}
+	apiError.Extra["fields"] = fields
+	return apiError
+}
+
+// MarshalJSON serializes the ApiError and adds the field messages under "fields".
+func (v *ValidationError) MarshalJSON() ([]byte, error) {
+	data, err := v.ApiError.MarshalJSON()
+	if err != nil || len(v.Fields) == 0 {
+		return data, err
+	}
+	return appendJSONField(data, "fields", v.Fields)
+}
+
+// UnmarshalJSON restores the ApiError and its field messages.
+func (v *ValidationError) UnmarshalJSON(data []byte) error {
+	if err := v.ApiError.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	aux := struct {
+		Fields map[string][]string `json:"fields"`
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	v.Fields = aux.Fields
+	delete(v.Extra, "fields")
+	if len(v.Extra) == 0 {
+		v.Extra = nil
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNewValidationError(t *testing.T) {
+	validationError := NewValidationError()
+
+	if validationError.ErrorType != UnprocessableEntityErrorType {
+		t.Errorf("expected error type %s, got %s", UnprocessableEntityErrorType, validationError.ErrorType)
+	}
+	if validationError.ErrorCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected error code %d, got %d", http.StatusUnprocessableEntity, validationError.ErrorCode)
+	}
+}
+
+func TestValidationErrorMarshalJSON(t *testing.T) {
+	// Arrange: add two messages to one field
+	validationError := NewValidationError(WithMessage("Validation failed")).
+		AddFieldError("email", "is required").
+		AddFieldError("email", "must be a valid address")
+
+	// Act
+	jsonData, err := json.Marshal(validationError)
+	if err != nil {
+		t.Fatalf("failed to marshal ValidationError: %v", err)
+	}
+
+	// Assert
+	expectedJSON := `{"error_type":"UnprocessableEntityError","message":"Validation failed","error_code":422,"status_text":"Unprocessable Entity","fields":{"email":["is required","must be a valid address"]}}`
+	if string(jsonData) != expectedJSON {
+		t.Errorf("expected %s, got %s", expectedJSON, string(jsonData))
+	}
+
+	var decoded ValidationError
+	if err := json.Unmarshal(jsonData, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+	if len(decoded.Fields["email"]) != 2 {
+		t.Errorf("expected 2 email messages, got %v", decoded.Fields["email"])
+	}
+	if decoded.Message != "Validation failed" {
+		t.Errorf("expected message %s, got %s", "Validation failed", decoded.Message)
+	}
+	if decoded.Extra != nil {
+		t.Errorf("expected no extra fields, got %v", decoded.Extra)
+	}
+}
+
+func TestValidationErrorIsUnprocessableEntity(t *testing.T) {
+	err := fmt.Errorf("create user: %w", NewValidationError().AddFieldError("name", "is required"))
+
+	if !IsUnprocessableEntity(err) {
+		t.Error("expected a wrapped ValidationError to match IsUnprocessableEntity")
+	}
+}
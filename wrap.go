@@ -0,0 +1,37 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Wrap attaches internal context to err without mutating it. If err is an *ApiError,
+// a copy with the same type and code is returned whose inner error joins the existing
+// one with internal; otherwise a GenericError wrapping both errors is created.
+func Wrap(err error, internal error) *ApiError {
+	if apiError, ok := baseApiError(err); ok && apiError != nil {
+		wrapped := apiError.Clone()
+		wrapped.InnerError = joinErrors(apiError.InnerError, internal)
+		return wrapped
+	}
+	return newApiError(GenericErrorType, "", []ErrorOption{WithInternalError(joinErrors(err, internal))})
+}
+
+// Wrapf creates an ApiError of errorType whose message is formatted with fmt.Sprintf
+// and whose inner error is err, so errors.Is, errors.As and errors.Unwrap still find err.
+// It is the ApiError counterpart of fmt.Errorf("...: %w", err).
+func Wrapf(err error, errorType string, format string, args ...any) *ApiError {
+	return newApiError(errorType, fmt.Sprintf(format, args...), []ErrorOption{WithInternalError(err)})
+}
+
+// joinErrors joins two possibly nil errors, avoiding a join wrapper when only one is set.
+func joinErrors(first, second error) error {
+	switch {
+	case first == nil:
+		return second
+	case second == nil:
+		return first
+	default:
+		return errors.Join(first, second)
+	}
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWrapExistingApiError(t *testing.T) {
+	// Arrange
+	firstErr := errors.New("no rows")
+	secondErr := errors.New("cache miss")
+	original := NotFound("User not found", WithInternalError(firstErr))
+
+	// Act
+	wrapped := Wrap(original, secondErr)
+
+	// Assert: type and code are preserved and both inner errors are reachable
+	if wrapped == original.ApiError {
+		t.Fatal("expected Wrap to return a copy")
+	}
+	if wrapped.ErrorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, wrapped.ErrorType)
+	}
+	if wrapped.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, wrapped.ErrorCode)
+	}
+	if !errors.Is(wrapped, firstErr) || !errors.Is(wrapped, secondErr) {
+		t.Error("expected both inner errors to be found in the chain")
+	}
+
+	// Assert: the original is not mutated
+	if original.InnerError != firstErr {
+		t.Errorf("expected original inner error %v, got %v", firstErr, original.InnerError)
+	}
+}
+
+func TestWrapApiErrorWithoutInnerError(t *testing.T) {
+	internalErr := errors.New("no rows")
+
+	wrapped := Wrap(NotFound("User not found"), internalErr)
+
+	if wrapped.InnerError != internalErr {
+		t.Errorf("expected inner error %v, got %v", internalErr, wrapped.InnerError)
+	}
+}
+
+func TestWrapPlainError(t *testing.T) {
+	// Arrange: a configured default type must not change the GenericError
+	t.Cleanup(func() { SetDefaultErrorType("") })
+	SetDefaultErrorType(BadRequestErrorType)
+	err := errors.New("read config")
+	internalErr := errors.New("permission denied")
+
+	// Act
+	wrapped := Wrap(err, internalErr)
+
+	// Assert
+	if wrapped.ErrorType != "GenericError" {
+		t.Errorf("expected error type %s, got %s", "GenericError", wrapped.ErrorType)
+	}
+	if wrapped.ErrorCode != http.StatusInternalServerError {
+		t.Errorf("expected error code %d, got %d", http.StatusInternalServerError, wrapped.ErrorCode)
+	}
+	if !errors.Is(wrapped, err) || !errors.Is(wrapped, internalErr) {
+		t.Error("expected both errors to be found in the chain")
+	}
+}
+
+func TestWrapDoesNotShareMetadata(t *testing.T) {
+	original := NotFound("User not found", WithMetadata("id", 42))
+
+	wrapped := Wrap(original, errors.New("cache miss"))
+	wrapped.Metadata["id"] = 7
+
+	if original.Metadata["id"] != 42 {
+		t.Errorf("expected original metadata id %d, got %v", 42, original.Metadata["id"])
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	// Arrange
+	rootErr := errors.New("no rows in result set")
+
+	// Act
+	apiError := Wrapf(rootErr, NotFoundErrorType, "user %d not found", 42)
+
+	// Assert
+	if apiError.Message != "user 42 not found" {
+		t.Errorf("expected message %s, got %s", "user 42 not found", apiError.Message)
+	}
+	if apiError.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, apiError.ErrorCode)
+	}
+	if !errors.Is(apiError, rootErr) {
+		t.Errorf("expected errors.Is to find %v", rootErr)
+	}
+	if errors.Unwrap(fmt.Errorf("handler: %w", apiError)) != apiError {
+		t.Error("expected the ApiError to unwrap from an outer fmt.Errorf")
+	}
+	if errors.Unwrap(apiError) != rootErr {
+		t.Errorf("expected unwrapped error %v, got %v", rootErr, errors.Unwrap(apiError))
+	}
+}
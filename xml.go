@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+// xmlApiError is the XML representation of an ApiError, using the JSON field names.
+// Metadata is not included because encoding/xml cannot encode maps.
+type xmlApiError struct {
+	ErrorType         string   `xml:"error_type"`
+	Message           string   `xml:"message"`
+	Details           []string `xml:"detail"`
+	ErrorCode         int      `xml:"error_code"`
+	AppCode           string   `xml:"code,omitempty"`
+	HelpURL           string   `xml:"help_url,omitempty"`
+	InternalError     string   `xml:"internal_error,omitempty"`
+	DeveloperMessage  string   `xml:"developer_message,omitempty"`
+	TraceID           string   `xml:"trace_id,omitempty"`
+	RetryAfterSeconds int      `xml:"retry_after_seconds,omitempty"`
+	Timestamp         string   `xml:"timestamp,omitempty"`
+}
+
+// MarshalXML implements xml.Marshaler. A top-level ApiError or typed error is encoded
+// as an <error> element. The inner error is written as text and, like the developer
+// message, left out while internal errors are redacted.
+func (e *ApiError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == "" || start.Name.Local == "ApiError" || isTypedErrorName(start.Name.Local) {
+		start.Name = xml.Name{Local: "error"}
+	}
+	aux := xmlApiError{
+		ErrorType:         e.ErrorType,
+		Message:           e.message(),
+		Details:           e.Details,
+		ErrorCode:         e.ErrorCode,
+		AppCode:           e.ApplicationCode,
+		HelpURL:           e.HelpURL,
+		TraceID:           e.TraceID,
+		RetryAfterSeconds: e.retryAfterSeconds(),
+	}
+	if !redactInternalErrors.Load() && !e.sanitized {
+		if e.InnerError != nil {
+			aux.InternalError = e.InnerError.Error()
+		}
+		aux.DeveloperMessage = e.DeveloperMessage
+	}
+	if !e.Timestamp.IsZero() {
+		aux.Timestamp = e.Timestamp.Format(time.RFC3339)
+	}
+	return enc.EncodeElement(aux, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (e *ApiError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var aux xmlApiError
+	if err := dec.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+	e.ErrorType = aux.ErrorType
+	e.Message = aux.Message
+	e.Details = aux.Details
+	e.ErrorCode = aux.ErrorCode
+	e.ApplicationCode = aux.AppCode
+	e.HelpURL = aux.HelpURL
+	e.TraceID = aux.TraceID
+	e.DeveloperMessage = aux.DeveloperMessage
+	if aux.InternalError != "" {
+		e.InnerError = errors.New(aux.InternalError)
+	}
+	if aux.RetryAfterSeconds > 0 {
+		e.RetryAfter = time.Duration(aux.RetryAfterSeconds) * time.Second
+	}
+	if aux.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, aux.Timestamp)
+		if err != nil {
+			return err
+		}
+		e.Timestamp = timestamp
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMarshalXML(t *testing.T) {
+	apiError := NotFound("User not found", WithInternalError(errors.New("no rows")))
+
+	xmlData, err := xml.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	expectedXML := `<error><error_type>NotFoundError</error_type><message>User not found</message><error_code>404</error_code><internal_error>no rows</internal_error></error>`
+	if string(xmlData) != expectedXML {
+		t.Errorf("expected %s, got %s", expectedXML, string(xmlData))
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	// Arrange
+	apiError := NotFound("User not found", WithInternalError(errors.New("no rows")), WithTraceID("4bf92f3577b34da6"))
+
+	// Act
+	xmlData, err := xml.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+	var decoded ApiError
+	if err := xml.Unmarshal(xmlData, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal XML: %v", err)
+	}
+
+	// Assert
+	if decoded.ErrorType != NotFoundErrorType {
+		t.Errorf("expected error type %s, got %s", NotFoundErrorType, decoded.ErrorType)
+	}
+	if decoded.Message != "User not found" {
+		t.Errorf("expected message %s, got %s", "User not found", decoded.Message)
+	}
+	if decoded.ErrorCode != http.StatusNotFound {
+		t.Errorf("expected error code %d, got %d", http.StatusNotFound, decoded.ErrorCode)
+	}
+	if decoded.InnerError == nil || decoded.InnerError.Error() != "no rows" {
+		t.Errorf("expected internal error %s, got %v", "no rows", decoded.InnerError)
+	}
+	if decoded.TraceID != "4bf92f3577b34da6" {
+		t.Errorf("expected trace ID %s, got %s", "4bf92f3577b34da6", decoded.TraceID)
+	}
+}
+
+func TestMarshalXMLAsStructField(t *testing.T) {
+	response := struct {
+		XMLName xml.Name  `xml:"response"`
+		Failure *ApiError `xml:"failure"`
+	}{Failure: BadRequest("Invalid input").ApiError}
+
+	xmlData, err := xml.Marshal(response)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+
+	expectedXML := `<response><failure><error_type>BadRequestError</error_type><message>Invalid input</message><error_code>400</error_code></failure></response>`
+	if string(xmlData) != expectedXML {
+		t.Errorf("expected %s, got %s", expectedXML, string(xmlData))
+	}
+}
+
+func TestMarshalXMLWithDetails(t *testing.T) {
+	apiError := BadRequest("Invalid password", WithDetail("too short"), WithDetail("no digit"))
+
+	xmlData, err := xml.Marshal(apiError)
+	if err != nil {
+		t.Fatalf("failed to marshal ApiError: %v", err)
+	}
+
+	expectedXML := `<error><error_type>BadRequestError</error_type><message>Invalid password</message><detail>too short</detail><detail>no digit</detail><error_code>400</error_code></error>`
+	if string(xmlData) != expectedXML {
+		t.Errorf("expected %s, got %s", expectedXML, string(xmlData))
+	}
+}